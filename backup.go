@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const backupDirName = "backups"
+
+// backupTickMsg is sent when the auto-backup interval elapses.
+type backupTickMsg time.Time
+
+func getBackupDir() (string, error) {
+	appConfigDir, err := getAppConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	backupDir := filepath.Join(appConfigDir, backupDirName)
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		return "", fmt.Errorf("could not create backup dir: %w", err)
+	}
+	return backupDir, nil
+}
+
+// writeBackup copies the current data file into the backup dir under a
+// timestamped name and prunes old backups down to keep. It returns the backup
+// path, or an empty path if there is no data file to back up yet.
+func writeBackup(keep int) (string, error) {
+	dataPath, err := getDataFilePath()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(dataPath)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not read data file: %w", err)
+	}
+
+	backupDir, err := getBackupDir()
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("data-%s.json", time.Now().Format("20060102-150405"))
+	path := filepath.Join(backupDir, name)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("could not write backup: %w", err)
+	}
+
+	if err := pruneBackups(backupDir, keep); err != nil {
+		return path, err
+	}
+	return path, nil
+}
+
+// pruneBackups removes all but the newest keep backups in dir. A keep of zero
+// or less keeps everything.
+func pruneBackups(dir string, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("could not list backups: %w", err)
+	}
+
+	var backups []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), "data-") && strings.HasSuffix(entry.Name(), ".json") {
+			backups = append(backups, entry.Name())
+		}
+	}
+
+	// Timestamped names sort chronologically.
+	sort.Strings(backups)
+	for len(backups) > keep {
+		if err := os.Remove(filepath.Join(dir, backups[0])); err != nil {
+			return fmt.Errorf("could not remove old backup: %w", err)
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// backupDataFile copies the data file to data.json.bak before a save
+// overwrites it, shifting older copies to data.json.bak.2 and so on and
+// dropping any beyond keep. A keep of zero or less turns it off.
+func backupDataFile(keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+	dataPath, err := getDataFilePath()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(dataPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not read data file: %w", err)
+	}
+
+	name := func(n int) string {
+		if n == 1 {
+			return dataPath + ".bak"
+		}
+		return fmt.Sprintf("%s.bak.%d", dataPath, n)
+	}
+	if err := os.Remove(name(keep)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not remove old backup: %w", err)
+	}
+	for n := keep - 1; n >= 1; n-- {
+		if err := os.Rename(name(n), name(n+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("could not rotate backups: %w", err)
+		}
+	}
+	if err := os.WriteFile(name(1), data, 0644); err != nil {
+		return fmt.Errorf("could not write backup: %w", err)
+	}
+	return nil
+}
+
+// scheduleBackup returns a command that fires the next interval backup, or nil
+// when interval backups are disabled.
+func (m *model) scheduleBackup() tea.Cmd {
+	if m.config.BackupIntervalMinutes <= 0 {
+		return nil
+	}
+	interval := time.Duration(m.config.BackupIntervalMinutes) * time.Minute
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return backupTickMsg(t)
+	})
+}
+
+// runBackup writes a backup and records when it happened.
+func (m *model) runBackup() {
+	path, err := writeBackup(m.config.BackupKeep)
+	if err != nil {
+		m.message = fmt.Sprintf("Error writing backup: %v", err)
+		return
+	}
+	if path != "" {
+		m.lastBackup = time.Now()
+		m.changesSinceBackup = 0
+	}
+}
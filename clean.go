@@ -0,0 +1,160 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// cleanReport counts what cleanProjects changed.
+type cleanReport struct {
+	hexes      int      // Hex codes rewritten to their canonical form
+	names      int      // Names with surrounding whitespace trimmed
+	colorDupes int      // Repeated colors removed
+	urlDupes   int      // Repeated URLs removed
+	invalid    []string // Colors left alone because they aren't hex codes
+	renamed    []string // Projects renamed because trimming made their name taken
+}
+
+func (r cleanReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Normalized hex codes: %d\n", r.hexes)
+	fmt.Fprintf(&b, "Trimmed names:        %d\n", r.names)
+	fmt.Fprintf(&b, "Duplicate colors:     %d\n", r.colorDupes)
+	fmt.Fprintf(&b, "Duplicate URLs:       %d\n", r.urlDupes)
+	for _, c := range r.invalid {
+		fmt.Fprintf(&b, "Not a hex color, left as is: %s\n", c)
+	}
+	for _, p := range r.renamed {
+		fmt.Fprintf(&b, "Renamed to keep names unique: %s\n", p)
+	}
+	return b.String()
+}
+
+// changes is the number of edits cleanProjects made.
+func (r cleanReport) changes() int {
+	return r.hexes + r.names + r.colorDupes + r.urlDupes + len(r.renamed)
+}
+
+// cleanProjects normalizes every hex code, trims whitespace from names and
+// drops repeated colors and URLs within each project, in place. A repeated
+// color passes its name and role on to the first copy if that has none, and
+// its copy count is added to it. A project whose trimmed name another project
+// already has gets a numbered name instead.
+func cleanProjects(projects []Project) cleanReport {
+	var r cleanReport
+	trim := func(s *string) {
+		if t := strings.TrimSpace(*s); t != *s {
+			*s = t
+			r.names++
+		}
+	}
+
+	// Project names are trimmed first, so a collision is resolved in favor
+	// of the project that already had the name.
+	var trimmed []int
+	for pi := range projects {
+		before := r.names
+		if trim(&projects[pi].Name); r.names != before {
+			trimmed = append(trimmed, pi)
+		}
+	}
+	for _, pi := range trimmed {
+		p := &projects[pi]
+		for i, other := range projects {
+			if i != pi && other.Name == p.Name {
+				name := uniqueProjectName(projects, p.Name)
+				r.renamed = append(r.renamed, fmt.Sprintf("'%s' -> '%s'", p.Name, name))
+				p.Name = name
+				break
+			}
+		}
+	}
+
+	for pi := range projects {
+		p := &projects[pi]
+
+		colors := []Color{}
+		seen := map[string]int{}
+		for _, c := range p.Colors {
+			trim(&c.Name)
+			if hex, ok := normalizeHex(c.Hex); ok {
+				if hex != c.Hex {
+					c.Hex = hex
+					r.hexes++
+				}
+			} else {
+				r.invalid = append(r.invalid, fmt.Sprintf("%s in '%s'", c.Hex, p.Name))
+			}
+			if i, ok := seen[c.Hex]; ok {
+				first := &colors[i]
+				if first.Name == "" {
+					first.Name = c.Name
+				}
+				if first.Role == "" {
+					first.Role = c.Role
+				}
+				first.Copies += c.Copies
+				r.colorDupes++
+				continue
+			}
+			seen[c.Hex] = len(colors)
+			colors = append(colors, c)
+		}
+		p.Colors = colors
+
+		urls := []namedURL{}
+		seenURLs := map[namedURL]bool{}
+		for _, u := range p.Urls {
+			trim(&u.Name)
+			if t := strings.TrimSpace(u.URL); t != u.URL {
+				u.URL = t
+				r.names++
+			}
+			if seenURLs[u] {
+				r.urlDupes++
+				continue
+			}
+			seenURLs[u] = true
+			urls = append(urls, u)
+		}
+		p.Urls = urls
+	}
+	return r
+}
+
+// runClean implements `diamonds clean [--apply]`. It reports what cleaning
+// the data file would change and only rewrites the file with --apply.
+func runClean(args []string) error {
+	flags := flag.NewFlagSet("clean", flag.ContinueOnError)
+	apply := flags.Bool("apply", false, "rewrite the data file instead of only reporting changes")
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	cfg, projects, err := loadForCLI()
+	if err != nil {
+		return err
+	}
+
+	report := cleanProjects(projects)
+	fmt.Print(report)
+	switch {
+	case report.changes() == 0:
+		fmt.Println("Nothing to clean.")
+	case !*apply:
+		fmt.Println("Dry run: run again with --apply to rewrite the data file.")
+	default:
+		m := model{config: cfg, projects: projects}
+		if m.writeProjects(); m.unsaved {
+			return fmt.Errorf("could not save cleaned data: %s", m.saveError)
+		}
+		if err := m.syncNow(); err != nil {
+			return fmt.Errorf("saved, but git sync failed: %w", err)
+		}
+		fmt.Println("Data file rewritten.")
+	}
+	return nil
+}
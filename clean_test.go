@@ -0,0 +1,21 @@
+package main
+
+import "testing"
+
+func TestCleanProjectsKeepsNamesUnique(t *testing.T) {
+	projects := []Project{{Name: "Brand "}, {Name: "Brand"}, {Name: " Site "}}
+	r := cleanProjects(projects)
+
+	want := []string{"Brand (2)", "Brand", "Site"}
+	for i, p := range projects {
+		if p.Name != want[i] {
+			t.Errorf("project %d named %q, want %q", i, p.Name, want[i])
+		}
+		if p.Colors == nil || p.Urls == nil {
+			t.Errorf("project %q has nil colors or URLs", p.Name)
+		}
+	}
+	if len(r.renamed) != 1 {
+		t.Errorf("renamed = %v, want one rename", r.renamed)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// loadForCLI loads the config and projects for a command that runs without
+// the TUI, asking for the passphrase when the data file is encrypted.
+func loadForCLI() (Config, []Project, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return cfg, nil, fmt.Errorf("could not load config: %w", err)
+	}
+	if encrypted := dataFileEncrypted(); encrypted || cfg.EncryptData {
+		if dataPassphrase, err = readPassphrase(!encrypted); err != nil {
+			return cfg, nil, err
+		}
+	}
+	projects, err := loadProjects()
+	if err != nil {
+		return cfg, nil, err
+	}
+	return cfg, projects, nil
+}
+
+// findProject returns the project called name, ignoring case.
+func findProject(projects []Project, name string) (Project, error) {
+	for _, p := range projects {
+		if strings.EqualFold(p.Name, name) {
+			return p, nil
+		}
+	}
+	return Project{}, fmt.Errorf("no project named '%s'", name)
+}
+
+// runList implements --list: one tab-separated line per project with its
+// color and URL counts.
+func runList() error {
+	_, projects, err := loadForCLI()
+	if err != nil {
+		return err
+	}
+	for _, p := range projects {
+		fmt.Fprintf(os.Stdout, "%s\t%d colors\t%d urls\n", p.Name, len(p.Colors), len(p.Urls))
+	}
+	return nil
+}
+
+// runListColors implements --list-colors: one line per color in the named
+// project, its hex followed by its name when it has one.
+func runListColors(name string) error {
+	cfg, projects, err := loadForCLI()
+	if err != nil {
+		return err
+	}
+	project, err := findProject(projects, name)
+	if err != nil {
+		return err
+	}
+	for _, c := range project.Colors {
+		line := displayHex(c.Hex, cfg)
+		if c.Name != "" {
+			line += "\t" + c.Name
+		}
+		fmt.Fprintln(os.Stdout, line)
+	}
+	return nil
+}
+
+// runExport implements --export: the named project as indented JSON.
+func runExport(name string) error {
+	_, projects, err := loadForCLI()
+	if err != nil {
+		return err
+	}
+	project, err := findProject(projects, name)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(project, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode project: %w", err)
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(data))
+	return err
+}
+
+// runImport implements --import: it reads a project as JSON from path, or
+// stdin when path is "-", and adds it to the data file. A name that's taken
+// gets a " (2)" style suffix. Nothing is saved unless every color is a valid
+// hex code and every URL is filled in.
+func runImport(path string) error {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("could not read project: %w", err)
+	}
+	var project Project
+	if err := json.Unmarshal(data, &project); err != nil {
+		return fmt.Errorf("could not parse project: %w", err)
+	}
+
+	cfg, projects, err := loadForCLI()
+	if err != nil {
+		return err
+	}
+	project.Name = strings.TrimSpace(project.Name)
+	if project.Name != "" {
+		project.Name = uniqueProjectName(projects, project.Name)
+	}
+	for i, c := range project.Colors {
+		if hex, ok := normalizeHex(c.Hex); ok {
+			project.Colors[i].Hex = hex
+		}
+	}
+	if err := validateProject(project, projects, -1); err != nil {
+		return fmt.Errorf("invalid project: %w", err)
+	}
+
+	m := model{config: cfg, projects: projects}
+	m.rememberProjects()
+	m.projects = append(m.projects, project)
+	m.stampProjects()
+	if m.writeProjects(); m.unsaved {
+		return fmt.Errorf("could not save: %s", m.saveError)
+	}
+	if err := m.syncNow(); err != nil {
+		return fmt.Errorf("saved, but git sync failed: %w", err)
+	}
+	fmt.Printf("Imported '%s' with %d colors and %d URLs\n", project.Name, len(project.Colors), len(project.Urls))
+	return nil
+}
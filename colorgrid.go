@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// colorGridCellWidth is the inner width of a color grid cell; the border
+// that marks the cursor adds two columns.
+const colorGridCellWidth = 12
+
+// colorGridColumns is how many cells fit across the terminal, or a fixed
+// count before the first tea.WindowSizeMsg.
+func (m *model) colorGridColumns() int {
+	width := m.contentWidth()
+	if width == 0 {
+		return 6
+	}
+	return max(width/(colorGridCellWidth+2), 1)
+}
+
+// colorGridCell draws the row at pos of the color list as a labeled swatch.
+// The cursor cell gets a visible border; the others a hidden one of the same
+// size, so the grid doesn't shift as the cursor moves.
+func (m *model) colorGridCell(pos int, row colorRow, size int) string {
+	fit := func(s string) string {
+		return ansi.Truncate(s, colorGridCellWidth, "…")
+	}
+
+	var lines []string
+	if row.index < 0 {
+		lines = []string{"", fit("▸ " + row.group), subtleStyle.Render(fmt.Sprintf("(%d)", size)), ""}
+	} else {
+		color := m.projects[m.selectedProject].Colors[row.index]
+		block := lipgloss.NewStyle().Background(lipgloss.Color(opaqueHex(color.Hex))).Render(strings.Repeat(" ", colorGridCellWidth))
+		label := color.Name
+		if label == "" {
+			label = color.Role
+		}
+		if pos := slices.Index(m.colorSelection, row.index); pos >= 0 {
+			label = fmt.Sprintf("[%d] %s", pos+1, label)
+		}
+		lines = []string{block, block, inlineCodeStyle.Render(displayHex(color.Hex, m.config)), subtleStyle.Render(fit(label))}
+	}
+
+	style := lipgloss.NewStyle().Width(colorGridCellWidth).Border(lipgloss.HiddenBorder())
+	if m.cursor == pos {
+		style = style.Border(lipgloss.RoundedBorder()).BorderForeground(selectionColor)
+	}
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// writeColorGrid appends the color list as a wrapping grid of swatches,
+// scrolled by grid row so the cursor stays visible.
+func (m *model) writeColorGrid(b *strings.Builder, rows []colorRow, sizes map[string]int, below string) {
+	cols := m.colorGridColumns()
+	var lines []string
+	for start := 0; start < len(rows); start += cols {
+		var cells []string
+		for pos := start; pos < min(start+cols, len(rows)); pos++ {
+			cells = append(cells, m.colorGridCell(pos, rows[pos], sizes[rows[pos].group]))
+		}
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, cells...)+"\n")
+	}
+	m.writeScrolled(b, lines, m.cursor/cols, below)
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// colorRow is a line the color list cursor can rest on: a color, or the
+// header of a collapsed section when index is -1.
+type colorRow struct {
+	index int    // Index into the project's colors, or -1 for a collapsed section
+	group string // Section the row belongs to, "" for ungrouped colors
+}
+
+// colorRows lists the selected project's colors in display order. Ungrouped
+// colors come first, then each section in the order its first color appears
+// in the current sort. A collapsed section is a single header row.
+func (m *model) colorRows() []colorRow {
+	project := m.projects[m.selectedProject]
+	var groups []string
+	members := map[string][]int{}
+	for _, i := range colorOrder(project.Colors, m.colorSort()) {
+		group := project.Colors[i].Group
+		if _, ok := members[group]; !ok && group != "" {
+			groups = append(groups, group)
+		}
+		members[group] = append(members[group], i)
+	}
+
+	var rows []colorRow
+	for _, i := range members[""] {
+		rows = append(rows, colorRow{index: i})
+	}
+	for _, group := range groups {
+		if slices.Contains(project.Collapsed, group) {
+			rows = append(rows, colorRow{index: -1, group: group})
+			continue
+		}
+		for _, i := range members[group] {
+			rows = append(rows, colorRow{index: i, group: group})
+		}
+	}
+	return rows
+}
+
+// colorGroups returns the section names used in the selected project, in
+// display order.
+func (m *model) colorGroups() []string {
+	var groups []string
+	for _, row := range m.colorRows() {
+		if row.group != "" && !slices.Contains(groups, row.group) {
+			groups = append(groups, row.group)
+		}
+	}
+	return groups
+}
+
+// colorGroupHeader is the section title shown above row's section, with a
+// count of its colors when it is collapsed.
+func colorGroupHeader(row colorRow, size int) string {
+	if row.index < 0 {
+		return fmt.Sprintf("▸ %s (%d)", row.group, size)
+	}
+	return "▾ " + row.group
+}
+
+// toggleColorGroup collapses or expands the section under the cursor and
+// keeps the cursor on that section.
+func (m *model) toggleColorGroup() {
+	rows := m.colorRows()
+	if m.cursor >= len(rows) || rows[m.cursor].group == "" {
+		m.message = "This color isn't in a section; press S to add it to one"
+		return
+	}
+	group := rows[m.cursor].group
+	project := &m.projects[m.selectedProject]
+	if pos := slices.Index(project.Collapsed, group); pos >= 0 {
+		project.Collapsed = slices.Delete(project.Collapsed, pos, pos+1)
+	} else {
+		project.Collapsed = append(project.Collapsed, group)
+	}
+	m.saveProjects()
+	for pos, row := range m.colorRows() {
+		if row.group == group {
+			m.cursor = pos
+			break
+		}
+	}
+}
+
+// moveColorInGroup reorders the color under the cursor within its section
+// for one of the move keys. Colors never leave their section this way.
+func (m *model) moveColorInGroup(key string) {
+	rows := m.colorRows()
+	if m.cursor >= len(rows) || rows[m.cursor].index < 0 {
+		return
+	}
+	row := rows[m.cursor]
+	first, last := m.cursor, m.cursor
+	for first > 0 && rows[first-1].group == row.group {
+		first--
+	}
+	for last < len(rows)-1 && rows[last+1].group == row.group {
+		last++
+	}
+
+	// Colors only trade places with others in their section, so the section's
+	// own position in the list never changes.
+	to := m.cursor
+	switch key {
+	case "shift+up", "K":
+		to = max(m.cursor-1, first)
+	case "shift+down", "J":
+		to = min(m.cursor+1, last)
+	case "T":
+		to = first
+	case "B":
+		to = last
+	}
+	if to == m.cursor {
+		return
+	}
+	colors := m.projects[m.selectedProject].Colors
+	step := 1
+	if to < m.cursor {
+		step = -1
+	}
+	for pos := m.cursor; pos != to; pos += step {
+		a, b := rows[pos].index, rows[pos+step].index
+		colors[a], colors[b] = colors[b], colors[a]
+	}
+	moved := rows[to].index
+	m.colorSelection = nil
+	m.saveProjects()
+	m.cursor = m.colorCursorFor(moved)
+}
+
+// startColorGroup opens the section form for the selected colors, or the
+// color under the cursor when none are selected.
+func (m *model) startColorGroup() {
+	index := m.selectedColorIndex()
+	if index < 0 {
+		return
+	}
+	m.currentView = ColorGroupView
+	m.inputBuffer = m.projects[m.selectedProject].Colors[index].Group
+}
+
+func (m *model) updateColorGroup(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc":
+		m.currentView = ColorListView
+		m.inputBuffer = ""
+	case "enter":
+		project := &m.projects[m.selectedProject]
+		group := strings.TrimSpace(m.inputBuffer)
+		targets := m.colorSelection
+		if len(targets) == 0 {
+			targets = []int{m.selectedColorIndex()}
+		}
+		for _, i := range targets {
+			project.Colors[i].Group = group
+		}
+		// Colors moved into a collapsed section should stay visible.
+		if pos := slices.Index(project.Collapsed, group); pos >= 0 {
+			project.Collapsed = slices.Delete(project.Collapsed, pos, pos+1)
+		}
+		m.saveProjects()
+		m.currentView = ColorListView
+		m.inputBuffer = ""
+		m.colorSelection = nil
+		m.cursor = m.colorCursorFor(targets[0])
+		if group == "" {
+			m.message = fmt.Sprintf("Removed %d colors from their section", len(targets))
+		} else {
+			m.message = fmt.Sprintf("Moved %d colors to '%s'", len(targets), group)
+		}
+	default:
+		m.inputBuffer = editInput(m.inputBuffer, msg)
+	}
+	return m, nil
+}
+
+func (m *model) viewColorGroup() string {
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Color Section") + "\n")
+	prompt := fmt.Sprintf("Section: %s", m.inputBuffer)
+	b.WriteString(inputStyle.Render(prompt) + "\n\n")
+	if n := len(m.colorSelection); n > 0 {
+		b.WriteString(helpStyle.Render(fmt.Sprintf("Applies to the %d selected colors", n)) + "\n")
+	}
+	if groups := m.colorGroups(); len(groups) > 0 {
+		b.WriteString(helpStyle.Render("Sections: "+strings.Join(groups, ", ")) + "\n")
+	}
+	b.WriteString(helpStyle.Render("Leave empty to take the color out of its section") + "\n")
+	b.WriteString(horizontalHelp("enter save", "esc cancel"))
+	return b.String()
+}
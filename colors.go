@@ -0,0 +1,546 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// isHexColor reports whether s is a #rgb, #rrggbb or #rrggbbaa color.
+func isHexColor(s string) bool {
+	if !strings.HasPrefix(s, "#") {
+		return false
+	}
+	digits := s[1:]
+	if len(digits) != 3 && len(digits) != 6 && len(digits) != 8 {
+		return false
+	}
+	for _, r := range digits {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeHex returns the canonical stored form of a #rgb, #rrggbb or
+// #rrggbbaa color: lowercase, with shorthand expanded to six digits. ok is
+// false when s isn't a hex color.
+func normalizeHex(s string) (hex string, ok bool) {
+	s = strings.TrimSpace(s)
+	if !isHexColor(s) {
+		return "", false
+	}
+	digits := strings.ToLower(s[1:])
+	if len(digits) == 3 {
+		digits = string([]byte{digits[0], digits[0], digits[1], digits[1], digits[2], digits[2]})
+	}
+	return "#" + digits, true
+}
+
+// parseColor reads a color typed by the user: a hex code, a CSS rgb() or
+// hsl() color, or an expression such as mix(#fff, #000). It returns the
+// canonical hex to store.
+func parseColor(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "(") {
+		hex, err := evalColorExpr(s)
+		if err != nil {
+			return "", err
+		}
+		s = hex
+	}
+	hex, ok := normalizeHex(s)
+	if !ok {
+		return "", fmt.Errorf("%q isn't a color; use #rgb, #rrggbb, #rrggbbaa, rgb() or hsl()", s)
+	}
+	return hex, nil
+}
+
+// opaqueHex is hex as "#rrggbb" for drawing: shorthand is expanded and any
+// alpha dropped, since terminals can't show it. Invalid values come back
+// unchanged.
+func opaqueHex(hex string) string {
+	if normalized, ok := normalizeHex(hex); ok {
+		return normalized[:7]
+	}
+	return hex
+}
+
+// hexToRGB parses a #rgb, #rrggbb or #rrggbbaa color into its red, green and
+// blue channels. Any alpha channel is ignored.
+func hexToRGB(hex string) (r, g, b uint8, ok bool) {
+	if !isHexColor(hex) {
+		return 0, 0, 0, false
+	}
+	digits := hex[1:]
+	if len(digits) == 3 {
+		digits = string([]byte{digits[0], digits[0], digits[1], digits[1], digits[2], digits[2]})
+	}
+	v, err := strconv.ParseUint(digits[:6], 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return uint8(v >> 16), uint8(v >> 8), uint8(v), true
+}
+
+// rgbToHex formats channels as a lowercase #rrggbb string.
+func rgbToHex(r, g, b uint8) string {
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// hexAlpha returns the two alpha digits of a #rrggbbaa color, or "" if hex has
+// no alpha channel.
+func hexAlpha(hex string) string {
+	if len(hex) == 9 {
+		return hex[7:]
+	}
+	return ""
+}
+
+// rgbToHSL converts channels to hue in degrees [0, 360) and saturation and
+// lightness in [0, 1].
+func rgbToHSL(r, g, b uint8) (h, s, l float64) {
+	rf, gf, bf := float64(r)/255, float64(g)/255, float64(b)/255
+	max := math.Max(rf, math.Max(gf, bf))
+	min := math.Min(rf, math.Min(gf, bf))
+	l = (max + min) / 2
+	if max == min {
+		return 0, 0, l
+	}
+
+	d := max - min
+	if l > 0.5 {
+		s = d / (2 - max - min)
+	} else {
+		s = d / (max + min)
+	}
+	switch max {
+	case rf:
+		h = (gf - bf) / d
+		if gf < bf {
+			h += 6
+		}
+	case gf:
+		h = (bf-rf)/d + 2
+	default:
+		h = (rf-gf)/d + 4
+	}
+	return h * 60, s, l
+}
+
+// hslToRGB converts hue in degrees and saturation and lightness in [0, 1]
+// back to channels.
+func hslToRGB(h, s, l float64) (r, g, b uint8) {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	s = clamp01(s)
+	l = clamp01(l)
+
+	if s == 0 {
+		v := uint8(math.Round(l * 255))
+		return v, v, v
+	}
+
+	var q float64
+	if l < 0.5 {
+		q = l * (1 + s)
+	} else {
+		q = l + s - l*s
+	}
+	p := 2*l - q
+	hk := h / 360
+	channel := func(t float64) uint8 {
+		if t < 0 {
+			t++
+		}
+		if t > 1 {
+			t--
+		}
+		var v float64
+		switch {
+		case t < 1.0/6:
+			v = p + (q-p)*6*t
+		case t < 1.0/2:
+			v = q
+		case t < 2.0/3:
+			v = p + (q-p)*(2.0/3-t)*6
+		default:
+			v = p
+		}
+		return uint8(math.Round(v * 255))
+	}
+	return channel(hk + 1.0/3), channel(hk), channel(hk - 1.0/3)
+}
+
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+// shiftHex rotates the hue of hex by hueDelta degrees and adds lightDelta
+// (in [-1, 1]) to its lightness, keeping any alpha channel. Invalid colors are
+// returned unchanged.
+func shiftHex(hex string, hueDelta, lightDelta float64) string {
+	r, g, b, ok := hexToRGB(hex)
+	if !ok {
+		return hex
+	}
+	h, s, l := rgbToHSL(r, g, b)
+	r, g, b = hslToRGB(h+hueDelta, s, l+lightDelta)
+	return rgbToHex(r, g, b) + hexAlpha(hex)
+}
+
+// colorFormat selects how a color is written to the clipboard.
+type colorFormat int
+
+const (
+	formatHex colorFormat = iota
+	formatRGB
+	formatHSL
+	formatNameHex
+	formatDataURI
+	formatOKLCH
+	colorFormatCount
+)
+
+func (f colorFormat) String() string {
+	switch f {
+	case formatRGB:
+		return "rgb"
+	case formatHSL:
+		return "hsl"
+	case formatNameHex:
+		return "name + hex"
+	case formatDataURI:
+		return "data URI"
+	case formatOKLCH:
+		return "OKLCH"
+	default:
+		return "hex"
+	}
+}
+
+// next returns the format that follows f, wrapping around.
+func (f colorFormat) next() colorFormat {
+	return (f + 1) % colorFormatCount
+}
+
+// formatColor renders hex in the given clipboard format.
+func formatColor(hex string, f colorFormat, cfg Config) (string, error) {
+	switch f {
+	case formatRGB:
+		return hexToRGBString(hex)
+	case formatHSL:
+		return hexToHSLString(hex)
+	case formatNameHex:
+		if name, _, ok := nearestColorName(hex); ok {
+			return name + " " + displayHex(hex, cfg), nil
+		}
+	case formatDataURI:
+		return swatchDataURI(hex, cfg.SwatchSize)
+	case formatOKLCH:
+		return hexToOKLCHString(hex)
+	}
+	return displayHex(hex, cfg), nil
+}
+
+// displayHex applies the hexCase option to a stored hex for display and
+// copying. The stored value itself is never changed.
+func displayHex(hex string, cfg Config) string {
+	switch cfg.HexCase {
+	case "upper":
+		return strings.ToUpper(hex)
+	case "lower":
+		return strings.ToLower(hex)
+	default:
+		return hex
+	}
+}
+
+// swatchDataURI encodes a size×size solid PNG of hex as a data URI, for
+// embedding a color chip in HTML or email.
+func swatchDataURI(hex string, size int) (string, error) {
+	r, g, b, ok := hexToRGB(hex)
+	if !ok {
+		return "", fmt.Errorf("invalid color %q", hex)
+	}
+	if size <= 0 {
+		size = defaultConfig().SwatchSize
+	}
+
+	a := uint8(255)
+	if alpha := hexAlpha(hex); alpha != "" {
+		v, _ := strconv.ParseUint(alpha, 16, 8)
+		a = uint8(v)
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: color.NRGBA{R: r, G: g, B: b, A: a}}, image.Point{}, draw.Src)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", fmt.Errorf("could not encode PNG: %w", err)
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// colorSort selects the order colors are listed in. Sorting only affects the
+// display; the stored order is kept.
+type colorSort int
+
+const (
+	colorSortManual colorSort = iota
+	colorSortCopies
+	colorSortName
+	colorSortHue
+	colorSortLuminance
+	colorSortCount
+)
+
+// colorSortNames are the names sorts are stored under in the data file.
+var colorSortNames = [colorSortCount]string{
+	colorSortManual:    "manual",
+	colorSortCopies:    "copies",
+	colorSortName:      "name",
+	colorSortHue:       "hue",
+	colorSortLuminance: "luminance",
+}
+
+func (s colorSort) String() string {
+	switch s {
+	case colorSortCopies:
+		return "most copied"
+	case colorSortName:
+		return "name"
+	case colorSortHue:
+		return "hue"
+	case colorSortLuminance:
+		return "luminance"
+	default:
+		return "manual"
+	}
+}
+
+func (s colorSort) next() colorSort {
+	return (s + 1) % colorSortCount
+}
+
+// parseColorSort looks up a stored sort name. Unknown and empty names mean
+// manual order.
+func parseColorSort(name string) colorSort {
+	for s, n := range colorSortNames {
+		if n == name {
+			return colorSort(s)
+		}
+	}
+	return colorSortManual
+}
+
+// colorOrder returns indexes into colors in the order s lists them.
+func colorOrder(colors []Color, s colorSort) []int {
+	order := make([]int, len(colors))
+	for i := range order {
+		order[i] = i
+	}
+	switch s {
+	case colorSortCopies:
+		sort.SliceStable(order, func(a, b int) bool {
+			return colors[order[a]].Copies > colors[order[b]].Copies
+		})
+	case colorSortName:
+		// Unnamed colors go last, ordered by hex.
+		key := func(c Color) string {
+			if c.Name == "" {
+				return "\uffff" + strings.ToLower(c.Hex)
+			}
+			return strings.ToLower(c.Name)
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return key(colors[order[a]]) < key(colors[order[b]])
+		})
+	case colorSortHue:
+		// Grays have no meaningful hue, so they go last, dark to light.
+		type hueKey struct {
+			gray   bool
+			hue, l float64
+		}
+		key := func(c Color) hueKey {
+			r, g, b, _ := hexToRGB(c.Hex)
+			h, s, l := rgbToHSL(r, g, b)
+			if s < 0.08 {
+				return hueKey{gray: true, l: l}
+			}
+			return hueKey{hue: h, l: l}
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			ka, kb := key(colors[order[a]]), key(colors[order[b]])
+			if ka.gray != kb.gray {
+				return kb.gray
+			}
+			if ka.hue != kb.hue {
+				return ka.hue < kb.hue
+			}
+			return ka.l < kb.l
+		})
+	case colorSortLuminance:
+		// Darkest first, by perceived (WCAG relative) luminance.
+		sort.SliceStable(order, func(a, b int) bool {
+			la, _ := relativeLuminance(colors[order[a]].Hex)
+			lb, _ := relativeLuminance(colors[order[b]].Hex)
+			return la < lb
+		})
+	}
+	return order
+}
+
+// hexToOKLCH converts a color to OKLCH: lightness in [0, 1], chroma, and hue
+// in degrees [0, 360).
+func hexToOKLCH(hex string) (l, c, h float64, ok bool) {
+	r, g, b, ok := hexToRGB(hex)
+	if !ok {
+		return 0, 0, 0, false
+	}
+
+	// sRGB to linear light.
+	linear := func(v uint8) float64 {
+		f := float64(v) / 255
+		if f <= 0.04045 {
+			return f / 12.92
+		}
+		return math.Pow((f+0.055)/1.055, 2.4)
+	}
+	lr, lg, lb := linear(r), linear(g), linear(b)
+
+	// Linear sRGB to OKLab, via the LMS cone response.
+	lms1 := math.Cbrt(0.4122214708*lr + 0.5363325363*lg + 0.0514459929*lb)
+	lms2 := math.Cbrt(0.2119034982*lr + 0.6806995451*lg + 0.1073969566*lb)
+	lms3 := math.Cbrt(0.0883024619*lr + 0.2817188376*lg + 0.6299787005*lb)
+	l = 0.2104542553*lms1 + 0.7936177850*lms2 - 0.0040720468*lms3
+	a := 1.9779984951*lms1 - 2.4285922050*lms2 + 0.4505937099*lms3
+	bb := 0.0259040371*lms1 + 0.7827717662*lms2 - 0.8086757660*lms3
+
+	// OKLab to polar OKLCH.
+	c = math.Hypot(a, bb)
+	h = math.Atan2(bb, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return l, c, h, true
+}
+
+// hexToOKLCHString formats a color as CSS oklch(), e.g.
+// "oklch(62.8% 0.258 29.23)". Achromatic colors get a hue of 0, and any alpha
+// channel is kept.
+func hexToOKLCHString(hex string) (string, error) {
+	l, c, h, ok := hexToOKLCH(hex)
+	if !ok {
+		return "", fmt.Errorf("invalid color %q", hex)
+	}
+	if c < 0.0005 {
+		c, h = 0, 0
+	}
+	s := fmt.Sprintf("oklch(%s%% %s %s", trimFloat(l*100, 1), trimFloat(c, 3), trimFloat(h, 2))
+	if alpha, ok := alphaFraction(hex); ok {
+		s += " / " + alpha
+	}
+	return s + ")", nil
+}
+
+// hexToRGBString formats a color as CSS rgb(), e.g. "rgb(255, 95, 135)", or
+// rgba() when it has an alpha channel.
+func hexToRGBString(hex string) (string, error) {
+	r, g, b, ok := hexToRGB(hex)
+	if !ok {
+		return "", fmt.Errorf("invalid color %q", hex)
+	}
+	if alpha, ok := alphaFraction(hex); ok {
+		return fmt.Sprintf("rgba(%d, %d, %d, %s)", r, g, b, alpha), nil
+	}
+	return fmt.Sprintf("rgb(%d, %d, %d)", r, g, b), nil
+}
+
+// hexToHSLString formats a color as CSS hsl(), e.g. "hsl(345, 100%, 68.6%)",
+// or hsla() when it has an alpha channel.
+func hexToHSLString(hex string) (string, error) {
+	r, g, b, ok := hexToRGB(hex)
+	if !ok {
+		return "", fmt.Errorf("invalid color %q", hex)
+	}
+	h, s, l := rgbToHSL(r, g, b)
+	channels := fmt.Sprintf("%s, %s%%, %s%%", trimFloat(h, 1), trimFloat(s*100, 1), trimFloat(l*100, 1))
+	if alpha, ok := alphaFraction(hex); ok {
+		return "hsla(" + channels + ", " + alpha + ")", nil
+	}
+	return "hsl(" + channels + ")", nil
+}
+
+// alphaFraction returns the alpha channel of a #rrggbbaa color as a fraction
+// like "0.5". ok is false when hex has no alpha channel.
+func alphaFraction(hex string) (string, bool) {
+	alpha := hexAlpha(hex)
+	if alpha == "" {
+		return "", false
+	}
+	v, _ := strconv.ParseUint(alpha, 16, 8)
+	return trimFloat(float64(v)/255, 2), true
+}
+
+// trimFloat formats v with at most prec decimals, dropping trailing zeros.
+func trimFloat(v float64, prec int) string {
+	return strconv.FormatFloat(math.Round(v*math.Pow10(prec))/math.Pow10(prec), 'f', -1, 64)
+}
+
+// relativeLuminance is the WCAG relative luminance of a color, from 0 for
+// black to 1 for white.
+func relativeLuminance(hex string) (float64, bool) {
+	r, g, b, ok := hexToRGB(hex)
+	if !ok {
+		return 0, false
+	}
+	channel := func(v uint8) float64 {
+		f := float64(v) / 255
+		if f <= 0.03928 {
+			return f / 12.92
+		}
+		return math.Pow((f+0.055)/1.055, 2.4)
+	}
+	return 0.2126*channel(r) + 0.7152*channel(g) + 0.0722*channel(b), true
+}
+
+// contrastRatio is the WCAG contrast ratio between two colors, from 1 to 21.
+func contrastRatio(a, b string) (float64, bool) {
+	la, okA := relativeLuminance(a)
+	lb, okB := relativeLuminance(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05), true
+}
+
+// wcagLevel names the best WCAG text level a contrast ratio passes: "AAA",
+// "AA", "AA large" (large text only) or "fail".
+func wcagLevel(ratio float64) string {
+	switch {
+	case ratio >= 7:
+		return "AAA"
+	case ratio >= 4.5:
+		return "AA"
+	case ratio >= 3:
+		return "AA large"
+	default:
+		return "fail"
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const configFileName = "config.json"
+
+// Config holds user preferences. It lives in config.json next to the data file
+// and any field missing from the file keeps its default value.
+type Config struct {
+	// AutoPrefixHash lets colors be entered without the leading '#'.
+	AutoPrefixHash bool `json:"autoPrefixHash"`
+	// QuitOnQ makes q quit from list views. When false only ctrl+c quits.
+	QuitOnQ bool `json:"quitOnQ"`
+	// Accent is a hex color that replaces the selection and input highlight
+	// colors. Empty or invalid values keep the default scheme.
+	Accent string `json:"accent"`
+	// CompactList starts the project list with one line per project. c toggles
+	// it while running.
+	CompactList bool `json:"compactList"`
+	// ProjectSort is the project list's order: "manual", "name", "size" (most
+	// colors and URLs first) or "recent" (last changed first). o cycles it and
+	// saves the choice here.
+	ProjectSort string `json:"projectSort"`
+	// ShowDescriptions shows each project's counts under its name in the
+	// project list. t toggles it and saves the choice here.
+	ShowDescriptions bool `json:"showDescriptions"`
+	// PromptScratchOnQuit asks whether to keep scratch projects when quitting.
+	// When false they are discarded silently.
+	PromptScratchOnQuit bool `json:"promptScratchOnQuit"`
+	// WrapNavigation makes moving past the end of a list wrap to the other end.
+	WrapNavigation bool `json:"wrapNavigation"`
+	// HexCase displays and copies hex codes as "upper" or "lower" case. Empty
+	// shows them as stored.
+	HexCase string `json:"hexCase"`
+	// NameFirst leads each color list item with the color's name and shows
+	// its hex dimmed after it. Unnamed colors always show the hex.
+	NameFirst bool `json:"nameFirst"`
+	// ShowIndex prefixes color and URL list items with their 1-based position.
+	ShowIndex bool `json:"showIndex"`
+	// DefaultURLFormat is how URLs are copied in projects that don't set their
+	// own format: "raw", "markdown", "named" or "clean".
+	DefaultURLFormat string `json:"defaultURLFormat"`
+	// StripClickIDs also removes fbclid and gclid when copying clean URLs.
+	StripClickIDs bool `json:"stripClickIDs"`
+	// ShowCopyCounts shows how many times each color has been copied.
+	ShowCopyCounts bool `json:"showCopyCounts"`
+	// ContrastBackground is the default color WCAG contrast badges are
+	// measured against. Empty uses black or white to match the terminal.
+	ContrastBackground string `json:"contrastBackground"`
+	// SlideshowSeconds is how long each project shows when the slideshow
+	// auto-plays.
+	SlideshowSeconds int `json:"slideshowSeconds"`
+	// DuplicateURLNames is what happens when a URL gets a name another URL in
+	// the project already has: "reject" refuses it, "disambiguate" appends the
+	// URL's domain, and "allow" keeps both as they are.
+	DuplicateURLNames string `json:"duplicateURLNames"`
+	// URLFormEnterSubmits makes enter save the URL form from the name field
+	// too once both fields are filled. Otherwise enter there moves to the URL
+	// field. ctrl+s saves from either field.
+	URLFormEnterSubmits bool `json:"urlFormEnterSubmits"`
+	// ExportWidth is the width text exports wrap to. Zero uses the terminal
+	// width.
+	ExportWidth int `json:"exportWidth"`
+	// SwatchSize is the width and height in pixels of PNG swatches copied as
+	// data URIs.
+	SwatchSize int `json:"swatchSize"`
+	// LegacyColors saves the old data file shape, a bare array of projects with
+	// colors as plain hex strings, for tools that expect it. Color names,
+	// roles and copy counts are dropped on save. Both shapes are always read.
+	LegacyColors bool `json:"legacyColors"`
+	// EncryptData encrypts the data file with a passphrase asked for at
+	// startup. Turning it off decrypts the file on the next save.
+	EncryptData bool `json:"encryptData"`
+	// ManualSaveOnRemovable switches to saving only on ctrl+s (and on quit)
+	// when the data file looks like it's on a removable or network drive,
+	// where every automatic write risks hanging the app.
+	ManualSaveOnRemovable bool `json:"manualSaveOnRemovable"`
+	// WatchDataFile watches the data file and offers to reload when it is
+	// changed outside the app, e.g. by another instance or a sync tool.
+	WatchDataFile bool `json:"watchDataFile"`
+	// GitSync commits the data file after each save when the data directory
+	// is inside a git repo.
+	GitSync bool `json:"gitSync"`
+	// GitPush also pushes after each git sync commit.
+	GitPush bool `json:"gitPush"`
+	// SeedWelcome adds a sample Welcome project on the first run.
+	SeedWelcome bool `json:"seedWelcome"`
+	// Seeded is set once the first-run Welcome project has been offered, so it
+	// is never added again.
+	Seeded bool `json:"seeded"`
+	// SaveBackups is how many copies of the previous data file to keep next to
+	// it as data.json.bak, data.json.bak.2 and so on, refreshed on every save.
+	// Zero turns them off.
+	SaveBackups int `json:"saveBackups"`
+	// BackupIntervalMinutes writes a timestamped backup every N minutes.
+	// Zero disables interval backups.
+	BackupIntervalMinutes int `json:"backupIntervalMinutes"`
+	// BackupEveryChanges writes a timestamped backup after every N saves.
+	// Zero disables change-count backups.
+	BackupEveryChanges int `json:"backupEveryChanges"`
+	// BackupKeep is how many timestamped backups to keep.
+	BackupKeep int `json:"backupKeep"`
+}
+
+func defaultConfig() Config {
+	return Config{
+		QuitOnQ:             true,
+		ShowDescriptions:    true,
+		PromptScratchOnQuit: true,
+		SeedWelcome:         true,
+		DefaultURLFormat:    "raw",
+		DuplicateURLNames:   urlNamesReject,
+		StripClickIDs:       true,
+		ShowCopyCounts:      true,
+		SwatchSize:          16,
+		SlideshowSeconds:    3,
+		BackupKeep:          10,
+		SaveBackups:         3,
+	}
+}
+
+func getConfigFilePath() (string, error) {
+	appConfigDir, err := getAppConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appConfigDir, configFileName), nil
+}
+
+func loadConfig() (Config, error) {
+	cfg := defaultConfig()
+
+	path, err := getConfigFilePath()
+	if err != nil {
+		return cfg, fmt.Errorf("could not get config file path: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil // No config, use defaults
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("could not read config file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return defaultConfig(), fmt.Errorf("could not parse config file: %w", err)
+	}
+	return cfg, nil
+}
+
+// setConfigValue writes a single option to config.json, leaving every other
+// option in the file as the user wrote it.
+func setConfigValue(key string, value any) error {
+	path, err := getConfigFilePath()
+	if err != nil {
+		return fmt.Errorf("could not get config file path: %w", err)
+	}
+
+	fields := map[string]json.RawMessage{}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not read config file: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return fmt.Errorf("could not parse config file: %w", err)
+		}
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	fields[key] = encoded
+
+	data, err = json.MarshalIndent(fields, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("could not write config file: %w", err)
+	}
+	return nil
+}
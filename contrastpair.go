@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// wcagThresholds are the contrast ratios ContrastView checks a pair against.
+var wcagThresholds = []struct {
+	label string
+	ratio float64
+}{
+	{"AA normal text", 4.5},
+	{"AA large text", 3},
+	{"AAA normal text", 7},
+	{"AAA large text", 4.5},
+}
+
+// compareColor marks the color under the cursor for ContrastView. Two
+// selected colors are compared right away; otherwise the first press marks a
+// color and the second, on another color, opens the comparison.
+func (m *model) compareColor() {
+	colors := m.projects[m.selectedProject].Colors
+	if len(m.colorSelection) == 2 {
+		m.compareColors = []Color{colors[m.colorSelection[0]], colors[m.colorSelection[1]]}
+		m.currentView = ContrastView
+		return
+	}
+	index := m.selectedColorIndex()
+	if index < 0 {
+		return
+	}
+	color := colors[index]
+	switch {
+	case len(m.compareColors) == 1 && m.compareColors[0] == color:
+		m.compareColors = nil
+		m.message = "Unmarked " + displayHex(color.Hex, m.config)
+	case len(m.compareColors) == 1:
+		m.compareColors = append(m.compareColors, color)
+		m.currentView = ContrastView
+	default:
+		m.compareColors = []Color{color}
+		m.message = fmt.Sprintf("Marked %s; press c on another color to compare", displayHex(color.Hex, m.config))
+	}
+}
+
+func (m *model) updateContrast(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc", "enter", "c":
+		m.compareColors = nil
+		m.currentView = ColorListView
+	}
+	return m, nil
+}
+
+func (m *model) viewContrast() string {
+	a, b := m.compareColors[0], m.compareColors[1]
+	// Terminals draw the samples without alpha, and shorthand must be expanded.
+	hexA, hexB := opaqueHex(a.Hex), opaqueHex(b.Hex)
+	var s strings.Builder
+	s.WriteString(headerStyle.Render("Contrast") + "\n")
+
+	describe := func(c Color) string {
+		line := swatch(opaqueHex(c.Hex)) + " " + inlineCodeStyle.Render(displayHex(c.Hex, m.config))
+		if c.Name != "" {
+			line += " " + c.Name
+		}
+		return line
+	}
+	s.WriteString(describe(a) + "\n" + describe(b) + "\n\n")
+
+	ratio, ok := contrastRatio(hexA, hexB)
+	if !ok {
+		s.WriteString(messageStyle.Render("Both colors need to be hex codes to compare them.") + "\n")
+		s.WriteString("\n" + horizontalHelp("esc back"))
+		return s.String()
+	}
+	s.WriteString(selectedItemStyle.Render(fmt.Sprintf("Ratio %.2f:1", ratio)) + "\n\n")
+
+	sample := func(fg, bg string) string {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(fg)).Background(lipgloss.Color(bg)).Render(" The quick brown fox ")
+	}
+	s.WriteString(sample(hexA, hexB) + "  " + sample(hexB, hexA) + "\n\n")
+
+	for _, t := range wcagThresholds {
+		result := "✗ fail"
+		if ratio >= t.ratio {
+			result = "✓ pass"
+		}
+		s.WriteString(fmt.Sprintf("%-16s %-6s %s\n", t.label, fmt.Sprintf("%g:1", t.ratio), result))
+	}
+
+	s.WriteString("\n" + horizontalHelp("esc back"))
+	return s.String()
+}
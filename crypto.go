@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// encryptedMagic starts every encrypted data file, so encrypted and plaintext
+// files can be told apart regardless of the encryptData option.
+var encryptedMagic = []byte("DIAMONDS-ENC1\n")
+
+const (
+	saltSize      = 16
+	keySize       = 32 // AES-256
+	kdfIterations = 600_000
+)
+
+// passphraseEnv lets scripts supply the passphrase without a prompt.
+const passphraseEnv = "DIAMONDS_PASSPHRASE"
+
+var errWrongPassphrase = errors.New("wrong passphrase or corrupted data file")
+
+// dataPassphrase is the passphrase for the encrypted data file, read once at
+// startup.
+var dataPassphrase string
+
+func isEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, encryptedMagic)
+}
+
+func deriveKey(passphrase string, salt []byte) ([]byte, error) {
+	return pbkdf2.Key(sha256.New, passphrase, salt, kdfIterations, keySize)
+}
+
+// encryptData seals plain with AES-GCM under a key derived from passphrase.
+// The output is the magic header, salt, nonce and ciphertext.
+func encryptData(plain []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("could not generate salt: %w", err)
+	}
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, fmt.Errorf("could not derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("could not generate nonce: %w", err)
+	}
+
+	out := append([]byte{}, encryptedMagic...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, plain, encryptedMagic), nil
+}
+
+// decryptData reverses encryptData. It returns errWrongPassphrase when the
+// data can't be authenticated with the given passphrase.
+func decryptData(data []byte, passphrase string) ([]byte, error) {
+	if !isEncrypted(data) {
+		return nil, errors.New("data is not encrypted")
+	}
+	data = data[len(encryptedMagic):]
+	if len(data) < saltSize {
+		return nil, errWrongPassphrase
+	}
+	salt, data := data[:saltSize], data[saltSize:]
+
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, fmt.Errorf("could not derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errWrongPassphrase
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+
+	plain, err := gcm.Open(nil, nonce, ciphertext, encryptedMagic)
+	if err != nil {
+		return nil, errWrongPassphrase
+	}
+	return plain, nil
+}
+
+// dataFileEncrypted reports whether the data file on disk is encrypted.
+func dataFileEncrypted() bool {
+	path, err := getDataFilePath()
+	if err != nil {
+		return false
+	}
+	data, err := os.ReadFile(path)
+	return err == nil && isEncrypted(data)
+}
+
+// readPassphrase returns the passphrase from $DIAMONDS_PASSPHRASE, or prompts
+// for it without echo. When confirm is set the prompt asks twice, for setting
+// a new passphrase.
+func readPassphrase(confirm bool) (string, error) {
+	if passphrase := os.Getenv(passphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return "", fmt.Errorf("data file is encrypted; set %s to unlock it", passphraseEnv)
+	}
+
+	prompt := func(label string) (string, error) {
+		fmt.Print(label)
+		passphrase, err := term.ReadPassword(os.Stdin.Fd())
+		fmt.Println()
+		if err != nil {
+			return "", fmt.Errorf("could not read passphrase: %w", err)
+		}
+		return string(passphrase), nil
+	}
+
+	passphrase, err := prompt("Passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", errors.New("passphrase must not be empty")
+	}
+	if confirm {
+		again, err := prompt("Confirm passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if again != passphrase {
+			return "", errors.New("passphrases do not match")
+		}
+	}
+	return passphrase, nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// editorFinishedMsg is sent when the external editor opened by
+// editProjectInEditor exits.
+type editorFinishedMsg struct {
+	path  string
+	index int
+	err   error
+}
+
+// editorCommand returns the user's editor from $VISUAL or $EDITOR, falling
+// back to vi. The variable may carry arguments, e.g. "code --wait".
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
+
+// editProjectInEditor writes the selected project to a temp JSON file and
+// suspends the TUI while $EDITOR runs on it.
+func (m *model) editProjectInEditor() tea.Cmd {
+	data, err := json.MarshalIndent(m.projects[m.selectedProject], "", "  ")
+	if err != nil {
+		m.message = fmt.Sprintf("Error encoding project: %v", err)
+		return nil
+	}
+
+	f, err := os.CreateTemp("", "diamonds-*.json")
+	if err != nil {
+		m.message = fmt.Sprintf("Error creating temp file: %v", err)
+		return nil
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		m.message = fmt.Sprintf("Error writing temp file: %v", err)
+		return nil
+	}
+
+	editor := editorCommand()
+	cmd := exec.Command(editor[0], append(editor[1:], f.Name())...)
+	path, index := f.Name(), m.selectedProject
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorFinishedMsg{path: path, index: index, err: err}
+	})
+}
+
+// applyEditedProject reads back the file from editProjectInEditor and
+// replaces the project with it if it is valid. Invalid edits are discarded.
+func (m *model) applyEditedProject(msg editorFinishedMsg) {
+	defer os.Remove(msg.path)
+
+	if msg.err != nil {
+		m.message = fmt.Sprintf("Editor failed, changes discarded: %v", msg.err)
+		return
+	}
+	data, err := os.ReadFile(msg.path)
+	if err != nil {
+		m.message = fmt.Sprintf("Error reading edited project: %v", err)
+		return
+	}
+
+	var edited Project
+	if err := json.Unmarshal(data, &edited); err != nil {
+		m.message = fmt.Sprintf("Invalid JSON, changes discarded: %v", err)
+		return
+	}
+	edited.Name = strings.TrimSpace(edited.Name)
+	// Store hexes the way the add color form does.
+	for i, c := range edited.Colors {
+		if hex, ok := normalizeHex(c.Hex); ok {
+			edited.Colors[i].Hex = hex
+		}
+	}
+	if err := validateProject(edited, m.projects, msg.index); err != nil {
+		m.message = fmt.Sprintf("Changes discarded: %v", err)
+		return
+	}
+	if edited.Colors == nil {
+		edited.Colors = []Color{}
+	}
+	if edited.Urls == nil {
+		edited.Urls = []namedURL{}
+	}
+
+	// Scratch isn't in the JSON, so a scratch project would otherwise be
+	// saved to the data file.
+	edited.Scratch = m.projects[msg.index].Scratch
+
+	m.renameProjectPrint(m.projects[msg.index].Name, edited.Name)
+	m.projects[msg.index] = edited
+	m.saveProjects()
+	m.updateProjectListItems()
+	m.message = fmt.Sprintf("Updated '%s' from editor", edited.Name)
+}
+
+// validateProject checks a project edited outside the app before it replaces
+// the project at index.
+func validateProject(p Project, projects []Project, index int) error {
+	if p.Name == "" {
+		return errors.New("project name is empty")
+	}
+	for i, other := range projects {
+		if i != index && other.Name == p.Name {
+			return fmt.Errorf("a project named '%s' already exists", p.Name)
+		}
+	}
+	for i, c := range p.Colors {
+		if !isHexColor(c.Hex) {
+			return fmt.Errorf("color %d: invalid hex %q", i+1, c.Hex)
+		}
+	}
+	for i, u := range p.Urls {
+		if strings.TrimSpace(u.URL) == "" {
+			return fmt.Errorf("URL %d is empty", i+1)
+		}
+	}
+	if _, ok := parseURLFormat(p.URLFormat); !ok && p.URLFormat != "" {
+		return fmt.Errorf("unknown URL format %q", p.URLFormat)
+	}
+	return nil
+}
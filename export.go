@@ -0,0 +1,235 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+)
+
+// exportOptions carries the settings exporters need to lay out their output.
+type exportOptions struct {
+	width int
+	cfg   Config
+}
+
+// exporter renders a project into a text format for the clipboard.
+type exporter struct {
+	name   string
+	render func(p Project, opts exportOptions) string
+}
+
+// exporters are listed in the export menu in this order.
+var exporters = []exporter{
+	{name: "Text table", render: formatColorTable},
+	{name: "Role tokens (JSON)", render: formatRoleTokens},
+	{name: "CSS custom properties", render: formatCSSVariables},
+	{name: "Tailwind colors", render: formatTailwind},
+}
+
+// formatColorTable lays the project's colors out as a plain-text table with as
+// many columns as fit in opts.width.
+func formatColorTable(p Project, opts exportOptions) string {
+	var b strings.Builder
+	b.WriteString(p.Name + "\n")
+	b.WriteString(strings.Repeat("=", min(len(p.Name), max(opts.width, 1))) + "\n")
+
+	cells := make([]string, len(p.Colors))
+	cellWidth := 0
+	for i, c := range p.Colors {
+		cells[i] = displayHex(c.Hex, opts.cfg)
+		if c.Name != "" {
+			cells[i] += " " + c.Name
+		}
+		cellWidth = max(cellWidth, len(cells[i]))
+	}
+	cellWidth += 2 // gutter
+
+	columns := max(1, opts.width/cellWidth)
+	for i, cell := range cells {
+		if (i+1)%columns == 0 || i == len(cells)-1 {
+			b.WriteString(cell + "\n")
+		} else {
+			b.WriteString(fmt.Sprintf("%-*s", cellWidth, cell))
+		}
+	}
+	return b.String()
+}
+
+// formatProjectBundle renders everything in a project as readable text for
+// sharing: its name and pinned note, then its colors and links in sections.
+func formatProjectBundle(p Project, cfg Config) string {
+	var b strings.Builder
+	b.WriteString(p.Name + "\n")
+	b.WriteString(strings.Repeat("=", len(p.Name)) + "\n")
+	if p.Pinned != "" {
+		b.WriteString(p.Pinned + "\n")
+	}
+
+	if len(p.Colors) > 0 {
+		b.WriteString("\nColors\n------\n")
+		for _, c := range p.Colors {
+			line := displayHex(c.Hex, cfg)
+			if c.Name != "" {
+				line += "  " + c.Name
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	if len(p.Urls) > 0 {
+		b.WriteString("\nLinks\n-----\n")
+		for _, u := range p.Urls {
+			b.WriteString(fmt.Sprintf("%s: %s\n", u.Name, u.URL))
+		}
+	}
+	return b.String()
+}
+
+// formatRoleTokens emits the project's role-assigned colors as W3C design
+// tokens keyed by role, in colorRoles order. Colors without a role are left
+// out.
+func formatRoleTokens(p Project, opts exportOptions) string {
+	var b strings.Builder
+	b.WriteString("{\n  \"color\": {\n")
+	var entries []string
+	for _, role := range colorRoles {
+		for _, c := range p.Colors {
+			if c.Role == role {
+				entries = append(entries, fmt.Sprintf("    %q: { \"$type\": \"color\", \"$value\": %q }", role, displayHex(c.Hex, opts.cfg)))
+				break
+			}
+		}
+	}
+	b.WriteString(strings.Join(entries, ",\n"))
+	if len(entries) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString("  }\n}\n")
+	return b.String()
+}
+
+// slugify lowercases s and joins its runs of letters and digits with dashes,
+// giving a name that is safe as a CSS identifier or object key.
+func slugify(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	return strings.Join(words, "-")
+}
+
+// colorKeys names each of the project's colors for exports: the slug of its
+// name when it has one, otherwise its 1-based position. A name slug already
+// used by an earlier color also falls back to the position. A position that
+// is also some color's name slug gets a "-2", "-3", ... suffix, so no two
+// colors share a key.
+func colorKeys(p Project) []string {
+	// Each name slug belongs to the first color with it.
+	owner := map[string]int{}
+	for i, c := range p.Colors {
+		if key := slugify(c.Name); key != "" {
+			if _, ok := owner[key]; !ok {
+				owner[key] = i
+			}
+		}
+	}
+
+	keys := make([]string, len(p.Colors))
+	used := map[string]bool{}
+	for i, c := range p.Colors {
+		key := slugify(c.Name)
+		if key == "" || owner[key] != i {
+			base := fmt.Sprint(i + 1)
+			key = base
+			for n := 2; ; n++ {
+				if _, named := owner[key]; !used[key] && !named {
+					break
+				}
+				key = fmt.Sprintf("%s-%d", base, n)
+			}
+		}
+		used[key] = true
+		keys[i] = key
+	}
+	return keys
+}
+
+// formatCSSVariables renders the project's colors as CSS custom properties
+// on :root, e.g. "--brand-primary: #ff5f87;".
+func formatCSSVariables(p Project, opts exportOptions) string {
+	prefix := slugify(p.Name)
+	if prefix == "" {
+		prefix = "color"
+	}
+	var b strings.Builder
+	b.WriteString(":root {\n")
+	for i, key := range colorKeys(p) {
+		fmt.Fprintf(&b, "  --%s-%s: %s;\n", prefix, key, displayHex(p.Colors[i].Hex, opts.cfg))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// formatTailwind renders the project's colors as a colors entry to paste into
+// theme.extend in tailwind.config.js, nested under the project's slug so the
+// classes read like "bg-brand-primary".
+func formatTailwind(p Project, opts exportOptions) string {
+	name := slugify(p.Name)
+	if name == "" {
+		name = "palette"
+	}
+	var b strings.Builder
+	b.WriteString("colors: {\n")
+	fmt.Fprintf(&b, "  '%s': {\n", name)
+	for i, key := range colorKeys(p) {
+		fmt.Fprintf(&b, "    '%s': '%s',\n", key, displayHex(p.Colors[i].Hex, opts.cfg))
+	}
+	b.WriteString("  },\n},\n")
+	return b.String()
+}
+
+// viewLines returns the rows of the current list view as plain text, in the
+// order and with the filter the view renders them.
+func (m *model) viewLines() []string {
+	var lines []string
+	switch m.currentView {
+	case ProjectListView:
+		for _, item := range m.projectList.VisibleItems() {
+			lines = append(lines, item.(projectItem).name)
+		}
+	case ColorListView:
+		project := m.projects[m.selectedProject]
+		for _, row := range m.colorRows() {
+			if row.index < 0 {
+				lines = append(lines, row.group+" (collapsed)")
+				continue
+			}
+			i := row.index
+			line := displayHex(project.Colors[i].Hex, m.config)
+			if project.Colors[i].Name != "" {
+				line += " " + project.Colors[i].Name
+			}
+			lines = append(lines, line)
+		}
+	case UrlListView:
+		project := m.projects[m.selectedProject]
+		for _, i := range m.visibleURLs() {
+			lines = append(lines, project.Urls[i].Name+" "+project.Urls[i].URL)
+		}
+	}
+	return lines
+}
+
+// copyViewAsText copies the current list view to the clipboard as plain text.
+func (m *model) copyViewAsText() {
+	lines := m.viewLines()
+	if len(lines) == 0 {
+		m.message = "Nothing to copy here"
+		return
+	}
+	if err := clipboard.WriteAll(strings.Join(lines, "\n") + "\n"); err != nil {
+		m.message = fmt.Sprintf("Error copying to clipboard: %v", err)
+		return
+	}
+	m.message = fmt.Sprintf("Copied %d lines to clipboard", len(lines))
+}
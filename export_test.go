@@ -0,0 +1,32 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestColorKeys(t *testing.T) {
+	tests := []struct {
+		name   string
+		colors []string
+		want   []string
+	}{
+		{"names and positions", []string{"Brand Primary", "", "Accent"}, []string{"brand-primary", "2", "accent"}},
+		{"repeated name", []string{"Red", "red"}, []string{"red", "2"}},
+		{"position taken by a later name", []string{"Red", "", "2"}, []string{"red", "2-2", "2"}},
+		{"position taken by an earlier name", []string{"2", ""}, []string{"2", "2-2"}},
+		{"repeated name on a taken position", []string{"1", "1"}, []string{"1", "2"}},
+		{"suffix taken too", []string{"", "1-2", "1"}, []string{"1-3", "1-2", "1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Project
+			for _, name := range tt.colors {
+				p.Colors = append(p.Colors, Color{Hex: "#000000", Name: name})
+			}
+			if got := colorKeys(p); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("colorKeys = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// evalColorExpr evaluates a color expression such as
+// "mix(#fff, lighten(#FF5F87, 10%), 25%)" to a #rrggbb hex. Supported
+// functions are mix, lighten, darken and saturate; arguments are hex literals,
+// nested expressions and percentages. CSS rgb(), rgba(), hsl() and hsla()
+// colors are read too, giving #rrggbbaa when they have an alpha below 1.
+func evalColorExpr(expr string) (string, error) {
+	p := &exprParser{input: expr}
+	hex, err := p.parseColor()
+	if err != nil {
+		return "", err
+	}
+	p.skipSpace()
+	if p.pos < len(p.input) {
+		return "", fmt.Errorf("unexpected %q at position %d", p.input[p.pos:], p.pos+1)
+	}
+	return hex, nil
+}
+
+type exprParser struct {
+	input string
+	pos   int
+}
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// expect consumes the byte c, skipping any spaces before it.
+func (p *exprParser) expect(c byte) error {
+	p.skipSpace()
+	if p.pos >= len(p.input) || p.input[p.pos] != c {
+		return fmt.Errorf("expected %q at position %d", c, p.pos+1)
+	}
+	p.pos++
+	return nil
+}
+
+// word consumes a run of bytes accepted by ok, skipping leading spaces.
+func (p *exprParser) word(ok func(byte) bool) string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.input) && ok(p.input[p.pos]) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *exprParser) parseColor() (string, error) {
+	p.skipSpace()
+	if p.pos < len(p.input) && p.input[p.pos] == '#' {
+		p.pos++
+		hex := "#" + p.word(isHexDigit)
+		r, g, b, ok := hexToRGB(hex)
+		if !ok {
+			return "", fmt.Errorf("invalid hex color %q", hex)
+		}
+		return rgbToHex(r, g, b), nil
+	}
+
+	name := strings.ToLower(p.word(func(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }))
+	if name == "" {
+		return "", fmt.Errorf("expected a color at position %d", p.pos+1)
+	}
+	if err := p.expect('('); err != nil {
+		return "", err
+	}
+
+	var result string
+	switch name {
+	case "mix":
+		a, err := p.parseColor()
+		if err != nil {
+			return "", err
+		}
+		if err := p.expect(','); err != nil {
+			return "", err
+		}
+		b, err := p.parseColor()
+		if err != nil {
+			return "", err
+		}
+		weight := 0.5
+		p.skipSpace()
+		if p.pos < len(p.input) && p.input[p.pos] == ',' {
+			p.pos++
+			if weight, err = p.parsePercent(); err != nil {
+				return "", err
+			}
+		}
+		result = mixHex(a, b, weight)
+	case "lighten", "darken", "saturate":
+		c, err := p.parseColor()
+		if err != nil {
+			return "", err
+		}
+		if err := p.expect(','); err != nil {
+			return "", err
+		}
+		amount, err := p.parsePercent()
+		if err != nil {
+			return "", err
+		}
+		result = adjustHex(name, c, amount)
+	case "rgb", "rgba", "hsl", "hsla":
+		var err error
+		if result, err = p.parseCSSColor(name); err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("unknown function %q", name)
+	}
+
+	if err := p.expect(')'); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// parseCSSColor reads the arguments of a CSS rgb() or hsl() color. Values
+// may be separated by commas or spaces, and an alpha may follow a comma or
+// "/". RGB channels are 0-255 or percentages; hue is in degrees.
+func (p *exprParser) parseCSSColor(name string) (string, error) {
+	var values [4]float64
+	var percent [4]bool
+	n := 0
+	for ; n < len(values); n++ {
+		p.skipSpace()
+		if n > 0 && p.pos < len(p.input) && (p.input[p.pos] == ',' || p.input[p.pos] == '/') {
+			p.pos++
+		}
+		num := p.word(func(c byte) bool { return c >= '0' && c <= '9' || c == '.' || c == '-' })
+		if num == "" {
+			break
+		}
+		v, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid number %q in %s()", num, name)
+		}
+		rest := p.input[p.pos:]
+		switch {
+		case strings.HasPrefix(rest, "%"):
+			p.pos++
+			percent[n] = true
+		case strings.HasPrefix(rest, "deg"):
+			p.pos += len("deg")
+		}
+		values[n] = v
+	}
+	if n < 3 {
+		return "", fmt.Errorf("%s() needs three values", name)
+	}
+
+	alpha := 1.0
+	if n == 4 {
+		alpha = values[3]
+		if percent[3] {
+			alpha /= 100
+		}
+		if alpha < 0 || alpha > 1 {
+			return "", fmt.Errorf("alpha in %s() must be between 0 and 1", name)
+		}
+	}
+
+	var r, g, b uint8
+	if strings.HasPrefix(name, "rgb") {
+		var channels [3]uint8
+		for i := range channels {
+			v := values[i]
+			if percent[i] {
+				v = v * 255 / 100
+			}
+			if v < 0 || v > 255 {
+				return "", fmt.Errorf("channel %g in %s() is out of range", values[i], name)
+			}
+			channels[i] = uint8(math.Round(v))
+		}
+		r, g, b = channels[0], channels[1], channels[2]
+	} else {
+		if values[1] < 0 || values[1] > 100 || values[2] < 0 || values[2] > 100 {
+			return "", fmt.Errorf("saturation and lightness in %s() must be 0-100%%", name)
+		}
+		r, g, b = hslToRGB(values[0], values[1]/100, values[2]/100)
+	}
+
+	hex := rgbToHex(r, g, b)
+	if alpha < 1 {
+		hex += fmt.Sprintf("%02x", uint8(math.Round(alpha*255)))
+	}
+	return hex, nil
+}
+
+// parsePercent reads a percentage like "10%" and returns it as a fraction.
+func (p *exprParser) parsePercent() (float64, error) {
+	num := p.word(func(c byte) bool { return c >= '0' && c <= '9' || c == '.' })
+	if err := p.expect('%'); err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil || v < 0 || v > 100 {
+		return 0, fmt.Errorf("invalid percentage %q", num+"%")
+	}
+	return v / 100, nil
+}
+
+func isHexDigit(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+// mixHex blends two colors channel by channel, with weight being the share of
+// a (as in Sass's mix).
+func mixHex(a, b string, weight float64) string {
+	ar, ag, ab, _ := hexToRGB(a)
+	br, bg, bb, _ := hexToRGB(b)
+	blend := func(x, y uint8) uint8 {
+		return uint8(float64(x)*weight + float64(y)*(1-weight) + 0.5)
+	}
+	return rgbToHex(blend(ar, br), blend(ag, bg), blend(ab, bb))
+}
+
+// adjustHex applies lighten, darken or saturate by amount in HSL space.
+func adjustHex(op, hex string, amount float64) string {
+	r, g, b, _ := hexToRGB(hex)
+	h, s, l := rgbToHSL(r, g, b)
+	switch op {
+	case "lighten":
+		l += amount
+	case "darken":
+		l -= amount
+	case "saturate":
+		s += amount
+	}
+	return rgbToHex(hslToRGB(h, s, l))
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// gitSyncMsg reports the result of a background git sync.
+type gitSyncMsg struct {
+	err error
+}
+
+// runGit runs git in dir and returns its combined output.
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	return strings.TrimSpace(out.String()), err
+}
+
+// gitSyncData commits the data file to the git repo containing it, and pushes
+// when push is set. Nothing is committed if the file hasn't changed.
+func gitSyncData(push bool) error {
+	path, err := getDataFilePath()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+
+	if _, err := runGit(dir, "rev-parse", "--is-inside-work-tree"); err != nil {
+		return errors.New("data directory is not in a git repo")
+	}
+	if out, err := runGit(dir, "add", "--", filepath.Base(path)); err != nil {
+		return fmt.Errorf("git add failed: %s", out)
+	}
+	// diff --quiet exits 1 when there are staged changes.
+	if _, err := runGit(dir, "diff", "--cached", "--quiet", "--", filepath.Base(path)); err == nil {
+		return nil
+	}
+	if out, err := runGit(dir, "commit", "-m", "Update diamonds data", "--", filepath.Base(path)); err != nil {
+		return fmt.Errorf("git commit failed: %s", out)
+	}
+	if push {
+		if out, err := runGit(dir, "push"); err != nil {
+			return fmt.Errorf("git push failed: %s", out)
+		}
+	}
+	return nil
+}
+
+// syncNow runs a pending git sync in the foreground, for commands that exit
+// right after saving and never start the background sync.
+func (m *model) syncNow() error {
+	if !m.gitSyncPending {
+		return nil
+	}
+	m.gitSyncPending = false
+	return gitSyncData(m.config.GitPush)
+}
+
+// gitSyncCmd runs a sync off the UI thread. Syncs run one at a time; saves
+// made while one is running are picked up by a follow-up sync.
+func (m *model) gitSyncCmd() tea.Cmd {
+	if !m.gitSyncPending || m.gitSyncing {
+		return nil
+	}
+	m.gitSyncPending = false
+	m.gitSyncing = true
+	push := m.config.GitPush
+	return func() tea.Msg {
+		return gitSyncMsg{err: gitSyncData(push)}
+	}
+}
+
+// finishGitSync handles a completed sync and starts the next one if more
+// saves happened meanwhile.
+func (m *model) finishGitSync(msg gitSyncMsg) tea.Cmd {
+	m.gitSyncing = false
+	if msg.err != nil {
+		m.message = fmt.Sprintf("Git sync: %v", msg.err)
+	}
+	return m.gitSyncCmd()
+}
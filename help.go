@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// helpGroupTitles maps the list views to their group in keyBindings, which
+// the ? overlay lists.
+var helpGroupTitles = map[ViewState]string{
+	ProjectListView: "Project list",
+	ProjectMenuView: "Project menu",
+	ColorListView:   "Colors",
+	UrlListView:     "URLs",
+}
+
+func (m *model) updateHelp(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		m.showHelp = false
+		return m.quit()
+	case "?", "esc", "q":
+		m.showHelp = false
+	}
+	return m, nil
+}
+
+func (m *model) viewHelp() string {
+	title := helpGroupTitles[m.currentView]
+	var bindings []keyBinding
+	for _, group := range m.keyBindings() {
+		if group.title == title {
+			bindings = group.bindings
+			break
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Keys: "+title) + "\n")
+	width := 0
+	for _, binding := range bindings {
+		width = max(width, utf8.RuneCountInString(binding.keys))
+	}
+	for _, binding := range bindings {
+		pad := strings.Repeat(" ", width-utf8.RuneCountInString(binding.keys))
+		b.WriteString(fmt.Sprintf("  %s%s  %s\n", binding.keys, pad, helpStyle.Render(binding.desc)))
+	}
+	b.WriteString("\n" + horizontalHelp("?/esc close"))
+	return b.String()
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// tokenNameSeparator joins nested design token group names into a single
+// color name, e.g. "brand.primary".
+const tokenNameSeparator = "."
+
+// importProjectFile reads a palette file from disk and converts it into a new
+// project named after the file. JSON files are read as design tokens or a
+// terminal theme, anything else as Xresources. It returns the number of
+// entries skipped.
+func importProjectFile(path string) (Project, int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Project{}, 0, fmt.Errorf("could not read file: %w", err)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	switch {
+	case !json.Valid(data):
+		return importXresources(data, name)
+	case isTerminalThemeJSON(data):
+		project, skipped, err := importTerminalThemeJSON(data, name)
+		if err != nil {
+			// Nothing usable as a theme, so it may still be design tokens.
+			return importDesignTokens(data, name)
+		}
+		return project, skipped, nil
+	default:
+		return importDesignTokens(data, name)
+	}
+}
+
+// importDesignTokens walks a W3C design tokens document and collects every
+// color token into a project. Nested groups are flattened into the token name
+// and tokens of any other type are skipped.
+func importDesignTokens(data []byte, name string) (Project, int, error) {
+	project := Project{Name: name, Colors: []Color{}, Urls: []namedURL{}}
+
+	var skipped int
+	aliases := map[string]string{}
+	var walk func(raw json.RawMessage, path []string, inheritedType string) error
+	walk = func(raw json.RawMessage, path []string, inheritedType string) error {
+		keys, fields, err := decodeOrderedObject(raw)
+		if err != nil {
+			return err
+		}
+
+		tokenType := inheritedType
+		if t, ok := fields["$type"]; ok {
+			if err := json.Unmarshal(t, &tokenType); err != nil {
+				return fmt.Errorf("invalid $type at %q: %w", strings.Join(path, tokenNameSeparator), err)
+			}
+		}
+
+		if value, ok := fields["$value"]; ok {
+			tokenName := strings.Join(path, tokenNameSeparator)
+			if tokenType != "" && tokenType != "color" {
+				skipped++
+				return nil
+			}
+			hex, ok := tokenColorValue(value)
+			switch {
+			case ok:
+				project.Colors = append(project.Colors, Color{Hex: hex, Name: tokenName})
+			case isTokenAlias(value):
+				aliases[tokenName] = tokenAliasTarget(value)
+				project.Colors = append(project.Colors, Color{Name: tokenName})
+			default:
+				skipped++
+			}
+			return nil
+		}
+
+		for _, key := range keys {
+			if strings.HasPrefix(key, "$") {
+				continue
+			}
+			if !bytes.HasPrefix(bytes.TrimSpace(fields[key]), []byte("{")) {
+				continue
+			}
+			if err := walk(fields[key], append(path[:len(path):len(path)], key), tokenType); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(data, nil, ""); err != nil {
+		return Project{}, 0, fmt.Errorf("could not parse design tokens: %w", err)
+	}
+
+	// Resolve aliases such as "{brand.primary}" against the colors collected
+	// above, dropping any that point at unknown or non-color tokens.
+	byName := map[string]string{}
+	for _, c := range project.Colors {
+		if c.Hex != "" {
+			byName[c.Name] = c.Hex
+		}
+	}
+	resolve := func(name string) string {
+		for i := 0; i <= len(aliases); i++ {
+			if hex := byName[name]; hex != "" {
+				return hex
+			}
+			next, ok := aliases[name]
+			if !ok {
+				return ""
+			}
+			name = next
+		}
+		return ""
+	}
+	colors := project.Colors[:0]
+	for _, c := range project.Colors {
+		if c.Hex == "" {
+			if c.Hex = resolve(c.Name); c.Hex == "" {
+				skipped++
+				continue
+			}
+		}
+		colors = append(colors, c)
+	}
+	project.Colors = colors
+
+	return project, skipped, nil
+}
+
+// tokenColorValue extracts a normalized hex from a color token's $value, which
+// is either a plain string or an object carrying a "hex" field.
+func tokenColorValue(raw json.RawMessage) (string, bool) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return normalizeHex(s)
+	}
+
+	var obj struct {
+		Hex string `json:"hex"`
+	}
+	if err := json.Unmarshal(raw, &obj); err == nil {
+		return normalizeHex(obj.Hex)
+	}
+	return "", false
+}
+
+func isTokenAlias(raw json.RawMessage) bool {
+	return tokenAliasTarget(raw) != ""
+}
+
+// tokenAliasTarget returns the referenced token name for an alias value like
+// "{brand.primary}", or an empty string when raw is not an alias.
+func tokenAliasTarget(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return ""
+	}
+	if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+		return ""
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}")
+}
+
+// decodeOrderedObject decodes a JSON object while keeping its key order, so
+// imported palettes keep the order they were authored in.
+func decodeOrderedObject(raw json.RawMessage) ([]string, map[string]json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, nil, fmt.Errorf("expected a JSON object")
+	}
+
+	var keys []string
+	fields := map[string]json.RawMessage{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("expected an object key")
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		if _, seen := fields[key]; !seen {
+			keys = append(keys, key)
+		}
+		fields[key] = value
+	}
+	return keys, fields, nil
+}
+
+// uniqueProjectName returns name, or name with a " (n)" suffix if a project
+// with that name already exists.
+func uniqueProjectName(projects []Project, name string) string {
+	taken := make(map[string]bool, len(projects))
+	for _, p := range projects {
+		taken[p.Name] = true
+	}
+	if !taken[name] {
+		return name
+	}
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s (%d)", name, n)
+		if !taken[candidate] {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestImportProjectFileJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []Color
+	}{
+		{
+			name: "design tokens with a colors group",
+			data: `{"colors": {"primary": {"$value": "#ff0000"}, "accent": {"$type": "color", "$value": "#00ff00"}}}`,
+			want: []Color{{Hex: "#ff0000", Name: "colors.primary"}, {Hex: "#00ff00", Name: "colors.accent"}},
+		},
+		{
+			name: "token hexes are normalized",
+			data: `{"brand": {"$type": "color", "light": {"$value": "#FFF"}, "glass": {"$value": "#FF5F87AA"}, "bad": {"$value": "#ggg"}}}`,
+			want: []Color{{Hex: "#ffffff", Name: "brand.light"}, {Hex: "#ff5f87aa", Name: "brand.glass"}},
+		},
+		{
+			name: "theme with a colors array",
+			data: `{"name": "Dusk", "colors": ["#000000", "#F00"], "background": "#111111"}`,
+			want: []Color{{Hex: "#000000", Name: "black"}, {Hex: "#ff0000", Name: "red"}, {Hex: "#111111", Name: "background"}},
+		},
+		{
+			name: "theme with a colors object",
+			data: `{"colors": {"color0": "#000000", "brightRed": "#ff5555", "foreground": "#eeeeee"}}`,
+			want: []Color{{Hex: "#000000", Name: "black"}, {Hex: "#ff5555", Name: "bright red"}, {Hex: "#eeeeee", Name: "foreground"}},
+		},
+		{
+			name: "Windows Terminal scheme",
+			data: `{"name": "Campbell", "black": "#0c0c0c", "brightWhite": "#f2f2f2"}`,
+			want: []Color{{Hex: "#0c0c0c", Name: "black"}, {Hex: "#f2f2f2", Name: "bright white"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "palette.json")
+			if err := os.WriteFile(path, []byte(tt.data), 0644); err != nil {
+				t.Fatal(err)
+			}
+			project, _, err := importProjectFile(path)
+			if err != nil {
+				t.Fatalf("importProjectFile: %v", err)
+			}
+			if !reflect.DeepEqual(project.Colors, tt.want) {
+				t.Errorf("colors = %+v, want %+v", project.Colors, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const keybindingsFileName = "keybindings.md"
+
+// keyBinding documents one key and what it does.
+type keyBinding struct {
+	keys string
+	desc string
+}
+
+// keyBindingGroup lists the keys available in one view.
+type keyBindingGroup struct {
+	title    string
+	bindings []keyBinding
+}
+
+// keyBindings returns every binding in the app, grouped by view. It reflects
+// config options that change bindings, so anything generated from it matches
+// the running app.
+func (m *model) keyBindings() []keyBindingGroup {
+	quit := keyBinding{"ctrl+c", "quit"}
+	// esc has nothing to go back to on the project list, so it quits there.
+	listQuit := quit
+	if m.config.QuitOnQ {
+		quit = keyBinding{"q, ctrl+c", "quit"}
+		listQuit = keyBinding{"q, esc, ctrl+c", "quit"}
+	}
+
+	return []keyBindingGroup{
+		{"Project list", []keyBinding{
+			{"↑/↓, k/j", "navigate"},
+			{"/", "filter projects (esc clears)"},
+			{"s", "search colors and URLs across all projects"},
+			{"←/→, h/l", "previous/next page"},
+			{"enter", "open project"},
+			{"n", "new project"},
+			{"N", "new scratch project (not saved)"},
+			{"i", "import project from file"},
+			{"y", "copy project name"},
+			{"Y", "copy primary (or first) color"},
+			{"d", "delete project"},
+			{"z", "undo last delete"},
+			{"c", "toggle compact list"},
+			{"t", "toggle descriptions"},
+			{"o", "cycle sort order (manual, name, size, recently changed)"},
+			{"shift+↑/↓, K/J", "move project"},
+			{"T/B", "move project to top/bottom"},
+			{"p", "palette slideshow"},
+			{"I", "info"},
+			{"F", "toggle focus mode"},
+			{"?", "show all keys for this view"},
+			{"ctrl+r", "reload data file"},
+			{"ctrl+s", "save now (manual save mode)"},
+			{"ctrl+e", "copy this list as plain text"},
+			listQuit,
+		}},
+		{"Project menu", []keyBinding{
+			{"↑/↓, k/j", "navigate"},
+			{"enter", "open colors or URLs"},
+			{"y", "copy project name"},
+			{"p", "edit pinned note"},
+			{"u", "cycle URL copy format"},
+			{"r", "rename project"},
+			{"s", "split into a new project"},
+			{"c", "copy project (opens the copy)"},
+			{"b", "copy project as a shareable text bundle"},
+			{"E", "edit project as JSON in $EDITOR"},
+			{"F", "toggle focus mode"},
+			{"?", "show all keys for this view"},
+			{"ctrl+r", "reload data file"},
+			{"ctrl+s", "save now (manual save mode)"},
+			{"esc", "back"},
+			quit,
+		}},
+		{"Colors", []keyBinding{
+			{"↑/↓, k/j", "navigate"},
+			{"←/→, h/l", "navigate the grid"},
+			{"g", "switch between list and swatch grid"},
+			{"enter", "copy color"},
+			{"alt+enter", "copy color and quit"},
+			{"f", "cycle copy format (hex, rgb, hsl, name + hex, data URI, OKLCH)"},
+			{"o", "cycle sort order (manual, most copied, name, hue, luminance)"},
+			{"O", "store the current sort as the manual order"},
+			{"v", "show names or hex codes first"},
+			{"space", "select/deselect color"},
+			{"G", "copy CSS gradient through selected colors"},
+			{"t", "add tints and shades of the color"},
+			{"i", "show/hide the nearest CSS color name (* when approximate)"},
+			{"w", "show/hide WCAG contrast badges"},
+			{"W", "use color as contrast background (again to reset)"},
+			{"c", "compare contrast: mark a color, then c on another (or select two)"},
+			{"R", "assign a role (primary, secondary, ...)"},
+			{"r", "rename color"},
+			{"S", "put color (or selected colors) in a section"},
+			{"m", "move color (or selected colors) to another project"},
+			{"tab", "fold/unfold the section under the cursor"},
+			{"shift+↑/↓, K/J", "move color"},
+			{"T/B", "move color to top/bottom"},
+			{"x", "export"},
+			{"n", "new color"},
+			{"e", "edit color value"},
+			{"d", "delete color"},
+			{"z", "undo last delete"},
+			{"H", "recolor (hue/lightness shift)"},
+			{"F", "toggle focus mode"},
+			{"?", "show all keys for this view"},
+			{"ctrl+r", "reload data file"},
+			{"ctrl+s", "save now (manual save mode)"},
+			{"ctrl+e", "copy this list as plain text"},
+			{"esc", "back"},
+			quit,
+		}},
+		{"URLs", []keyBinding{
+			{"↑/↓, k/j", "navigate"},
+			{"enter", "copy URL"},
+			{"alt+enter", "copy URL and quit"},
+			{"f", "cycle this project's URL copy format"},
+			{"space", "show/hide details"},
+			{"/", "filter by name or address (esc clears)"},
+			{"o", "cycle sort order"},
+			{"shift+↑/↓, K/J", "move URL"},
+			{"T/B", "move URL to top/bottom"},
+			{"n", "new URL"},
+			{"P", "paste many URLs"},
+			{"e", "edit URL name and address"},
+			{"r", "rename URL"},
+			{"d", "delete URL"},
+			{"z", "undo last delete"},
+			{"F", "toggle focus mode"},
+			{"?", "show all keys for this view"},
+			{"ctrl+r", "reload data file"},
+			{"ctrl+s", "save now (manual save mode)"},
+			{"ctrl+e", "copy this list as plain text"},
+			{"esc", "back"},
+			quit,
+		}},
+		{"Recolor", []keyBinding{
+			{"←/→, h/l", "shift hue"},
+			{"↑/↓, k/j", "shift lightness"},
+			{"enter", "apply"},
+			{"c", "apply to a copy of the project"},
+			{"esc", "cancel"},
+		}},
+		{"Export", []keyBinding{
+			{"↑/↓, k/j", "navigate"},
+			{"enter", "copy export"},
+			{"esc", "back"},
+		}},
+		{"Split project", []keyBinding{
+			{"↑/↓, k/j", "navigate"},
+			{"space", "select item"},
+			{"enter", "name the new project"},
+			{"esc", "cancel"},
+		}},
+		{"Color role", []keyBinding{
+			{"↑/↓, k/j", "navigate"},
+			{"enter", "assign role"},
+			{"esc", "back"},
+		}},
+		{"Tints and shades", []keyBinding{
+			{"+/=, →/l", "more steps"},
+			{"-, ←/h", "fewer steps"},
+			{"enter, y", "add the new colors"},
+			{"esc, n", "cancel"},
+		}},
+		{"Contrast", []keyBinding{
+			{"esc, enter, c", "back"},
+		}},
+		{"Search", []keyBinding{
+			{"↑/↓, ctrl+k/ctrl+j", "navigate results"},
+			{"enter", "open the result"},
+			{"esc", "back"},
+		}},
+		{"Move colors", []keyBinding{
+			{"↑/↓, k/j", "pick the project"},
+			{"enter", "move"},
+			{"esc", "back"},
+		}},
+		{"Slideshow", []keyBinding{
+			{"←/→, h/l, space", "previous/next project"},
+			{"a", "start/stop auto-play"},
+			{"esc", "back"},
+		}},
+		{"Unsaved changes", []keyBinding{
+			{"y", "quit without saving"},
+			{"s", "try saving again, then quit"},
+			{"n, esc", "cancel"},
+			{"ctrl+c", "quit without saving"},
+		}},
+		{"Keep scratch projects", []keyBinding{
+			{"y", "keep and quit"},
+			{"n, ctrl+c", "discard and quit"},
+			{"esc", "cancel"},
+		}},
+		{"Delete confirmation", []keyBinding{
+			{"y", "delete"},
+			{"n, esc", "cancel"},
+		}},
+		{"Forms", []keyBinding{
+			{"enter", "save (in the URL form: next field, then save)"},
+			{"ctrl+s", "save the URL form from either field"},
+			{"tab, shift+tab", "switch fields"},
+			{"ctrl+v", "paste a color into the color form"},
+			{"esc", "cancel"},
+			{"ctrl+c", "quit"},
+		}},
+		{"Paste URLs", []keyBinding{
+			{"enter", "new line"},
+			{"tab", "separate name from URL"},
+			{"ctrl+s", "add all URLs"},
+			{"esc", "cancel"},
+		}},
+		{"Info", []keyBinding{
+			{"e", "export keybinding reference"},
+			{"I, esc", "back"},
+		}},
+		{"Help", []keyBinding{
+			{"?, esc, q", "close"},
+		}},
+	}
+}
+
+// formatKeybindingReference renders groups as a Markdown cheatsheet.
+func formatKeybindingReference(groups []keyBindingGroup) string {
+	var b strings.Builder
+	b.WriteString("# Diamonds keybindings\n")
+	for _, group := range groups {
+		b.WriteString("\n## " + group.title + "\n\n")
+		b.WriteString("| Key | Action |\n")
+		b.WriteString("| --- | --- |\n")
+		for _, binding := range group.bindings {
+			b.WriteString(fmt.Sprintf("| `%s` | %s |\n", binding.keys, binding.desc))
+		}
+	}
+	return b.String()
+}
+
+// writeKeybindingReference writes the current keybindings to a Markdown file
+// in the app config dir and returns its path.
+func (m *model) writeKeybindingReference() (string, error) {
+	appConfigDir, err := getAppConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(appConfigDir, keybindingsFileName)
+	if err := os.WriteFile(path, []byte(formatKeybindingReference(m.keyBindings())), 0644); err != nil {
+		return "", fmt.Errorf("could not write keybinding reference: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// keyHandlerGroups maps each key handler to the keyBindings group that
+// documents it.
+var keyHandlerGroups = map[string]string{
+	"updateProjectList":          "Project list",
+	"updateProjectMenu":          "Project menu",
+	"updateColorList":            "Colors",
+	"moveColorInGroup":           "Colors",
+	"updateUrlList":              "URLs",
+	"moveURL":                    "URLs",
+	"updateRecolor":              "Recolor",
+	"updateExportMenu":           "Export",
+	"updateSplitSelect":          "Split project",
+	"updateRolePicker":           "Color role",
+	"updateRamp":                 "Tints and shades",
+	"updateContrast":             "Contrast",
+	"updateSearch":               "Search",
+	"updateMoveColor":            "Move colors",
+	"updateSlideshow":            "Slideshow",
+	"updateUnsavedQuit":          "Unsaved changes",
+	"updateScratchQuit":          "Keep scratch projects",
+	"updateConfirmDeleteProject": "Delete confirmation",
+	"updateAddProject":           "Forms",
+	"updateAddColor":             "Forms",
+	"updateAddUrl":               "Forms",
+	"updateSplitName":            "Forms",
+	"updatePinnedNote":           "Forms",
+	"updateImport":               "Forms",
+	"updateInlineRename":         "Forms",
+	"updateUrlFilter":            "Forms",
+	"updateColorGroup":           "Forms",
+	"editInput":                  "Forms",
+	"updateBulkUrl":              "Paste URLs",
+	"updateInfo":                 "Info",
+	"updateHelp":                 "Help",
+}
+
+// unlistedKeys are handled without being listed in a group: ctrl+c quits
+// from every view, and forms take space and backspace as typing.
+var unlistedKeys = map[string]map[string]bool{
+	"":      {"ctrl+c": true},
+	"Forms": {" ": true, "backspace": true},
+}
+
+// bindingKeys expands a keyBinding's keys, such as "shift+↑/↓, K/J", into
+// the msg.String() values they stand for.
+func bindingKeys(keys string) []string {
+	names := map[string]string{"↑": "up", "↓": "down", "←": "left", "→": "right", "space": " "}
+	var expanded []string
+	for _, item := range strings.Split(keys, ", ") {
+		parts := []string{item}
+		if item != "/" {
+			parts = strings.Split(item, "/")
+		}
+		// "shift+↑/↓" applies the modifier to both keys.
+		prefix := ""
+		if i := strings.LastIndex(parts[0], "+"); i > 0 {
+			prefix = parts[0][:i+1]
+		}
+		for j, part := range parts {
+			if j > 0 && !strings.Contains(part, "+") {
+				part = prefix + part
+			}
+			for symbol, name := range names {
+				part = strings.Replace(part, symbol, name, 1)
+			}
+			expanded = append(expanded, part)
+		}
+	}
+	return expanded
+}
+
+// handledKeys returns the string case labels in each function of the
+// package's non-test files, by function name.
+func handledKeys(t *testing.T) map[string][]string {
+	t.Helper()
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	keys := map[string][]string{}
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				clause, ok := n.(*ast.CaseClause)
+				if !ok {
+					return true
+				}
+				for _, expr := range clause.List {
+					if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						key, _ := strconv.Unquote(lit.Value)
+						keys[fn.Name.Name] = append(keys[fn.Name.Name], key)
+					}
+				}
+				return true
+			})
+		}
+	}
+	return keys
+}
+
+// TestKeyBindingsCoverHandledKeys checks that every key an update method
+// switches on is listed in its view's keyBindings group, so the help overlay
+// and the keybinding reference don't miss keys.
+func TestKeyBindingsCoverHandledKeys(t *testing.T) {
+	m := &model{config: Config{QuitOnQ: true}}
+	registered := map[string]map[string]bool{}
+	for _, group := range m.keyBindings() {
+		registered[group.title] = map[string]bool{}
+		for _, binding := range group.bindings {
+			for _, key := range bindingKeys(binding.keys) {
+				registered[group.title][key] = true
+			}
+		}
+	}
+
+	for fn, keys := range handledKeys(t) {
+		title, ok := keyHandlerGroups[fn]
+		if !ok {
+			if strings.HasPrefix(fn, "update") {
+				t.Errorf("%s handles keys but has no keyBindings group in keyHandlerGroups", fn)
+			}
+			continue
+		}
+		if registered[title] == nil {
+			t.Errorf("%s maps to missing keyBindings group %q", fn, title)
+			continue
+		}
+		for _, key := range keys {
+			if !registered[title][key] && !unlistedKeys[""][key] && !unlistedKeys[title][key] {
+				t.Errorf("%s handles %q, which the %q keyBindings group doesn't list", fn, key, title)
+			}
+		}
+	}
+}
@@ -2,50 +2,201 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
+	"math"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
 )
 
 const dataFileName = "data.json"
 const configDirName = "diamonds"
 
-func getDataFilePath() (string, error) {
-	configDir, err := os.UserConfigDir()
+// dirLookup holds the environment lookups used to find the app config dir, so
+// the fallback order can be exercised without touching the real environment.
+type dirLookup struct {
+	userConfigDir func() (string, error)
+	getenv        func(string) string
+	tempDir       func() string
+	mkdirAll      func(string, os.FileMode) error
+}
+
+var defaultDirLookup = dirLookup{
+	userConfigDir: os.UserConfigDir,
+	getenv:        os.Getenv,
+	tempDir:       os.TempDir,
+	mkdirAll:      os.MkdirAll,
+}
+
+// appConfigDirWarning is set when the app config dir had to fall back to the
+// temp dir, where data may not survive a reboot.
+var appConfigDirWarning string
+
+// appConfigDir returns the first usable diamonds directory from
+// os.UserConfigDir, $XDG_CONFIG_HOME, $HOME/.config and finally the temp dir.
+// The warning is non-empty when the temp dir was used.
+func (l dirLookup) appConfigDir() (dir string, warning string, err error) {
+	var candidates []string
+	if configDir, err := l.userConfigDir(); err == nil && filepath.IsAbs(configDir) {
+		candidates = append(candidates, configDir)
+	}
+	if xdg := l.getenv("XDG_CONFIG_HOME"); filepath.IsAbs(xdg) {
+		candidates = append(candidates, xdg)
+	}
+	if home := l.getenv("HOME"); filepath.IsAbs(home) {
+		candidates = append(candidates, filepath.Join(home, ".config"))
+	}
+
+	for _, candidate := range candidates {
+		appConfigDir := filepath.Join(candidate, configDirName)
+		if err := l.mkdirAll(appConfigDir, 0755); err == nil {
+			return appConfigDir, "", nil
+		}
+	}
+
+	appConfigDir := filepath.Join(l.tempDir(), configDirName)
+	if err := l.mkdirAll(appConfigDir, 0755); err != nil {
+		return "", "", fmt.Errorf("could not create app config dir: %w", err)
+	}
+	warning = fmt.Sprintf("No usable config dir found, storing data in %s", appConfigDir)
+	return appConfigDir, warning, nil
+}
+
+func getAppConfigDir() (string, error) {
+	appConfigDir, warning, err := defaultDirLookup.appConfigDir()
 	if err != nil {
-		return "", fmt.Errorf("could not get user config dir: %w", err)
+		return "", err
 	}
+	appConfigDirWarning = warning
+	return appConfigDir, nil
+}
 
-	appConfigDir := filepath.Join(configDir, configDirName)
-	if err := os.MkdirAll(appConfigDir, 0755); err != nil {
-		return "", fmt.Errorf("could not create app config dir: %w", err)
+// dataFileOverride is the --data-file flag: a data file to use instead of the
+// one in the app config dir.
+var dataFileOverride string
+
+func getDataFilePath() (string, error) {
+	if dataFileOverride != "" {
+		path, err := filepath.Abs(dataFileOverride)
+		if err != nil {
+			return "", fmt.Errorf("could not resolve data file path: %w", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return "", fmt.Errorf("could not create data file dir: %w", err)
+		}
+		return path, nil
 	}
 
+	appConfigDir, err := getAppConfigDir()
+	if err != nil {
+		return "", err
+	}
 	return filepath.Join(appConfigDir, dataFileName), nil
 }
 
 func (m *model) saveProjects() {
+	m.stampProjects()
+	// Cleared once the write succeeds.
+	m.unsaved = true
+	if m.manualSave {
+		return
+	}
+	m.writeProjects()
+}
+
+// writeProjects writes the projects to the data file now, even in manual save
+// mode. Failures are kept in saveError so the footer keeps showing them until
+// a write succeeds.
+func (m *model) writeProjects() {
+	m.unsaved = true
+	failed := func(format string, err error) {
+		m.saveError = fmt.Sprintf(format, err)
+		m.message = m.saveError
+	}
+
 	path, err := getDataFilePath()
 	if err != nil {
-		m.message = fmt.Sprintf("Error getting data path: %v", err)
+		failed("Error getting data path: %v", err)
 		return
 	}
 
-	data, err := json.MarshalIndent(m.projects, "", "  ")
+	var toSave any = dataFile{Version: currentSchemaVersion, Projects: savedProjects(m.projects)}
+	if m.config.LegacyColors {
+		toSave = legacyProjects(savedProjects(m.projects))
+	}
+	data, err := json.MarshalIndent(toSave, "", "  ")
 	if err != nil {
-		m.message = fmt.Sprintf("Error saving data: %v", err)
+		failed("Error saving data: %v", err)
+		return
+	}
+
+	if m.config.EncryptData {
+		if data, err = encryptData(data, dataPassphrase); err != nil {
+			failed("Error encrypting data: %v", err)
+			return
+		}
+	}
+
+	// A failed backup is reported but doesn't hold up the save.
+	if err := backupDataFile(m.config.SaveBackups); err != nil {
+		m.message = fmt.Sprintf("Error backing up data file: %v", err)
+	}
+	if err := writeFileAtomic(path, data, 0644); err != nil {
+		failed("Error writing data: %v", err)
 		return
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil {
-		m.message = fmt.Sprintf("Error writing data: %v", err)
+	m.unsaved = false
+	m.saveError = ""
+	m.dataHash = hashData(data)
+	m.changesSinceBackup++
+	m.gitSyncPending = m.config.GitSync
+	if m.config.BackupEveryChanges > 0 && m.changesSinceBackup >= m.config.BackupEveryChanges {
+		m.runBackup()
+	}
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it
+// into place, so a crash mid-write leaves the old file intact instead of a
+// truncated one.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("could not create temp file: %w", err)
+	}
+	// Harmless once the rename has happened.
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("could not write temp file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("could not flush temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("could not close temp file: %w", err)
 	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return fmt.Errorf("could not set permissions: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("could not replace data file: %w", err)
+	}
+	return nil
 }
 
 func loadProjects() ([]Project, error) {
@@ -63,8 +214,14 @@ func loadProjects() ([]Project, error) {
 		return nil, fmt.Errorf("could not read data file: %w", err)
 	}
 
-	var projects []Project
-	if err := json.Unmarshal(data, &projects); err != nil {
+	if isEncrypted(data) {
+		if data, err = decryptData(data, dataPassphrase); err != nil {
+			return nil, fmt.Errorf("could not decrypt data file: %w", err)
+		}
+	}
+
+	projects, err := migrate(data)
+	if err != nil {
 		return nil, fmt.Errorf("could not parse data file: %w", err)
 	}
 
@@ -83,6 +240,23 @@ const (
 	AddUrlView
 	ProjectMenuView
 	ConfirmDeleteProjectView
+	ImportView
+	RecolorView
+	PinnedNoteView
+	InfoView
+	ExportMenuView
+	SplitSelectView
+	SplitNameView
+	BulkUrlView
+	ScratchQuitView
+	UnsavedQuitView
+	RolePickerView
+	SlideshowView
+	ColorGroupView
+	MoveColorView
+	SearchView
+	ContrastView
+	RampView
 )
 
 // --- LIST ITEM (Project) ---
@@ -90,6 +264,7 @@ type projectItem struct {
 	name       string
 	colorCount int
 	urlCount   int
+	scratch    bool
 }
 
 func (p projectItem) FilterValue() string { return p.name }
@@ -103,7 +278,36 @@ func (p projectItem) Description() string {
 	if p.urlCount == 1 {
 		urlStr = "URL"
 	}
-	return fmt.Sprintf("%d %s, %d %s", p.colorCount, colorStr, p.urlCount, urlStr)
+	desc := fmt.Sprintf("%d %s, %d %s", p.colorCount, colorStr, p.urlCount, urlStr)
+	if p.scratch {
+		desc = "scratch · " + desc
+	}
+	return desc
+}
+
+// compactDelegate renders each project on a single line with its counts
+// inline, fitting more projects on screen than the default delegate.
+type compactDelegate struct{}
+
+func (d compactDelegate) Height() int                         { return 1 }
+func (d compactDelegate) Spacing() int                        { return 0 }
+func (d compactDelegate) Update(tea.Msg, *list.Model) tea.Cmd { return nil }
+func (d compactDelegate) Render(w io.Writer, l list.Model, index int, item list.Item) {
+	p, ok := item.(projectItem)
+	if !ok {
+		return
+	}
+	counts := fmt.Sprintf("%d·%d", p.colorCount, p.urlCount)
+	if p.scratch {
+		counts += " scratch"
+	}
+	name := ansi.Truncate(p.name, max(l.Width()-len("> ")-len(" ")-lipgloss.Width(counts), 1), "…")
+	counts = subtleStyle.Render(counts)
+	if index == l.Index() {
+		fmt.Fprintf(w, "%s %s", selectedItemStyle.Render("> "+name), counts)
+		return
+	}
+	fmt.Fprintf(w, "  %s %s", name, counts)
 }
 
 // --- MODEL ---
@@ -112,10 +316,137 @@ type namedURL struct {
 	URL  string `json:"url"`
 }
 
+// Color is a single palette entry. Older data files stored colors as bare hex
+// strings, so Color also unmarshals from a plain JSON string.
+type Color struct {
+	Hex    string `json:"hex"`
+	Name   string `json:"name,omitempty"`
+	Source string `json:"source,omitempty"` // Expression the color was computed from
+	Copies int    `json:"copies,omitempty"` // Times the color has been copied
+	Role   string `json:"role,omitempty"`   // One of colorRoles, held by one color per project
+	Group  string `json:"group,omitempty"`  // Section the color is listed under, e.g. "Brand"
+}
+
+func (c *Color) UnmarshalJSON(data []byte) error {
+	var hex string
+	if err := json.Unmarshal(data, &hex); err == nil {
+		*c = Color{Hex: hex}
+		return nil
+	}
+
+	type plainColor Color
+	var pc plainColor
+	if err := json.Unmarshal(data, &pc); err != nil {
+		return err
+	}
+	*c = Color(pc)
+	return nil
+}
+
+// legacyProject is the data file shape from before colors had metadata, with
+// colors as bare hex strings. Its Colors field shadows the embedded one.
+type legacyProject struct {
+	Project
+	Colors []string `json:"colors"`
+}
+
+// legacyProjects converts projects to the legacy shape, dropping color names,
+// sources, copy counts and roles.
+func legacyProjects(projects []Project) []legacyProject {
+	legacy := make([]legacyProject, len(projects))
+	for i, p := range projects {
+		legacy[i] = legacyProject{Project: p, Colors: make([]string, len(p.Colors))}
+		for j, c := range p.Colors {
+			legacy[i].Colors[j] = c.Hex
+		}
+	}
+	return legacy
+}
+
 type Project struct {
 	Name   string     `json:"name"`
-	Colors []string   `json:"colors"`
+	Colors []Color    `json:"colors"`
 	Urls   []namedURL `json:"urls"`
+	Pinned string     `json:"pinned,omitempty"` // One-line note shown as a banner
+	// URLFormat overrides the defaultURLFormat option for this project's URLs.
+	URLFormat string `json:"urlFormat,omitempty"`
+	// ColorSort and URLSort are how this project's lists are sorted, each
+	// independently. Empty means manual order.
+	ColorSort string `json:"colorSort,omitempty"`
+	URLSort   string `json:"urlSort,omitempty"`
+	// Background is the color contrast badges are measured against. Empty
+	// uses the contrastBackground option.
+	Background string `json:"background,omitempty"`
+	// Collapsed lists the color sections folded away in the color list.
+	Collapsed []string `json:"collapsed,omitempty"`
+	// CreatedAt and UpdatedAt are when the project was added and last
+	// changed. Projects saved before timestamps existed have zero values.
+	CreatedAt time.Time `json:"createdAt,omitzero"`
+	UpdatedAt time.Time `json:"updatedAt,omitzero"`
+	// Scratch projects live only for the session and are never saved.
+	Scratch bool `json:"-"`
+}
+
+// moveItem moves the element at index from to index to, shifting the elements
+// in between. It reports whether anything moved, so out-of-range moves at the
+// ends of a list are no-ops.
+func moveItem[T any](items []T, from, to int) bool {
+	if from == to || from < 0 || to < 0 || from >= len(items) || to >= len(items) {
+		return false
+	}
+	item := items[from]
+	if from < to {
+		copy(items[from:to], items[from+1:to+1])
+	} else {
+		copy(items[to+1:from+1], items[to:from])
+	}
+	items[to] = item
+	return true
+}
+
+// moveToEnd moves the element at index from to the top or bottom of items and
+// returns its new index. It reports false if the element was already there.
+func moveToEnd[T any](items []T, from int, top bool) (int, bool) {
+	to := len(items) - 1
+	if top {
+		to = 0
+	}
+	return to, moveItem(items, from, to)
+}
+
+// splitProject partitions p into the items it keeps and the items picked in
+// selected, which indexes colors first and then URLs.
+func splitProject(p Project, selected map[int]bool) (kept, split Project) {
+	kept = p
+	kept.Colors = []Color{}
+	kept.Urls = []namedURL{}
+	split = Project{Colors: []Color{}, Urls: []namedURL{}}
+
+	for i, c := range p.Colors {
+		if selected[i] {
+			split.Colors = append(split.Colors, c)
+		} else {
+			kept.Colors = append(kept.Colors, c)
+		}
+	}
+	for i, u := range p.Urls {
+		if selected[len(p.Colors)+i] {
+			split.Urls = append(split.Urls, u)
+		} else {
+			kept.Urls = append(kept.Urls, u)
+		}
+	}
+	return kept, split
+}
+
+// cloneProject returns a deep copy of p so the copy's slices can be edited
+// without touching the original.
+func cloneProject(p Project) Project {
+	clone := p
+	clone.Colors = append([]Color{}, p.Colors...)
+	clone.Urls = append([]namedURL{}, p.Urls...)
+	clone.Collapsed = slices.Clone(p.Collapsed)
+	return clone
 }
 
 type model struct {
@@ -126,8 +457,66 @@ type model struct {
 	selectedProject int
 	inputBuffer     string // Used for single-line inputs
 	urlNameBuffer   string // Used for the URL name in AddUrlView
-	focusedField    int    // Used in AddUrlView to track focus
+	colorNameBuffer string // Used for the optional color name in AddColorView
+	focusedField    int    // Used in AddUrlView and AddColorView to track focus
+	editingIndex    int    // Item an Add*View form is editing, or -1 when adding
 	message         string
+	config          Config
+	expandedURL     int     // Index of the URL showing its details in UrlListView, or -1
+	colorFormat     colorFormat
+	colorSelection  []int   // Color indexes picked in ColorListView, in the order picked
+	showContrast    bool    // Shows WCAG contrast badges in ColorListView
+	showCSSNames    bool    // Shows the nearest CSS named color in ColorListView
+	colorGrid       bool    // ColorListView shows a grid of swatches instead of a list
+	slide           int     // Index of the project shown in SlideshowView
+	slideAuto       bool    // SlideshowView advances on a timer
+	slideSeq        int     // Invalidates slideshow ticks from earlier auto-play runs
+	roleCursor      int     // Used in RolePickerView
+	destCursor      int     // Used in MoveColorView
+	compareColors   []Color // Colors marked with c for ContrastView, first then second
+	rampIndex       int     // Base color of RampView
+	rampSteps       int     // Tints and shades RampView generates each way
+	urlFilter       string  // Narrows UrlListView to URLs whose name or address contains it
+	urlFiltering    bool    // The URL filter is being typed
+	renaming        bool    // A color or URL name is being edited in its list
+	renameIndex     int     // Index of the color or URL being renamed
+	renameBuffer    string  // Name being typed during an inline rename
+	hueShift        float64 // Used in RecolorView, in degrees
+	lightShift      float64 // Used in RecolorView, in lightness percentage points
+
+	lastBackup         time.Time
+	changesSinceBackup int
+
+	width         int          // Terminal width from the last tea.WindowSizeMsg
+	height        int          // Terminal height from the last tea.WindowSizeMsg
+	listOffset    int          // First row shown in a scrolled color or URL list
+	exportCursor  int          // Used in ExportMenuView
+	splitSelected map[int]bool // Items picked in SplitSelectView; colors first, then URLs
+
+	undo    *pendingUndo
+	undoSeq int
+
+	messageSeq int // Invalidates message timeouts scheduled for earlier messages
+
+	unsaved     bool   // Changes aren't on disk: the last save failed or waits for ctrl+s
+	saveError   string // Why the last save failed, shown until a save succeeds
+	manualSave  bool   // Saves wait for ctrl+s because the data file is on a flaky drive
+	reloadArmed bool   // ctrl+r was pressed once with unsaved changes
+
+	quitFrom ViewState // View to return to when quitting is cancelled in UnsavedQuitView
+
+	projectPrints map[string]string // Each project's content when last saved, by name
+
+	dataHash    string          // Hash of the data file as last written or loaded
+	dataChanges <-chan struct{} // Data file watcher events, nil when not watching
+	dataChanged bool            // The data file changed on disk outside the app
+
+	gitSyncing     bool // A git sync command is running
+	gitSyncPending bool // Saved since the last git sync started
+
+	compactList bool // One line per project in the project list
+	focusMode   bool // Hides footers and status chrome in list views
+	showHelp    bool // The ? overlay lists every key of the current list view
 }
 
 // --- STYLING PARAMETERS ---
@@ -137,7 +526,7 @@ var (
 	// Comment: Gray text for secondary info
 	commentColor = lipgloss.Color("#757575")
 	// Flag: Adaptive color for selected items
-	selectionColor = lipgloss.AdaptiveColor{Light: "#0000CD", Dark: "#BAF3EB"}
+	selectionColor lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#0000CD", Dark: "#BAF3EB"}
 	itemDescColor = lipgloss.AdaptiveColor{Light: "#5151D8", Dark: "#E9F8F5"}
 	// ErrorHeader: Used for status messages
 	messageColor   = lipgloss.Color("#F1F1F1")
@@ -146,7 +535,7 @@ var (
 	inlineCodeColor   = lipgloss.Color("#FF5F87")
 	inlineCodeBgColor = lipgloss.AdaptiveColor{Light: "#ADD8E6", Dark: "#3A3A3A"}
 	// Quote: Adaptive pink for interactive elements
-	quoteColor = lipgloss.AdaptiveColor{Light: "#1E90FF", Dark: "#FF59C8"}
+	quoteColor lipgloss.TerminalColor = lipgloss.AdaptiveColor{Light: "#1E90FF", Dark: "#FF59C8"}
 	// Normal: For regular text
 	normalTextColor = lipgloss.AdaptiveColor{Light: "#1F2026", Dark: "#E5E5E5"}
 
@@ -193,6 +582,20 @@ var (
 	docStyle = lipgloss.NewStyle().Padding(2,1).Foreground(normalTextColor)
 )
 
+// applyAccent tints the selection and input colors with a single hex accent
+// and rebuilds the styles derived from them. Invalid colors leave the default
+// scheme in place.
+func applyAccent(hex string) error {
+	if !isHexColor(hex) {
+		return fmt.Errorf("invalid accent color %q", hex)
+	}
+	selectionColor = lipgloss.Color(hex)
+	quoteColor = lipgloss.Color(hex)
+	selectedItemStyle = selectedItemStyle.Foreground(selectionColor)
+	inputStyle = inputStyle.BorderForeground(quoteColor)
+	return nil
+}
+
 func newCustomDelegate() list.DefaultDelegate {  
 	// Create a new default delegate  
 	d := list.NewDefaultDelegate()  
@@ -209,49 +612,178 @@ func newCustomDelegate() list.DefaultDelegate {
 	return d  
 }
 
+// projectDelegate returns the delegate for the project list: the compact
+// one-line delegate, or the default one with or without descriptions.
+func projectDelegate(compact, showDescriptions bool) list.ItemDelegate {
+	if compact {
+		return compactDelegate{}
+	}
+	d := newCustomDelegate()
+	d.ShowDescription = showDescriptions
+	return d
+}
+
 // --- INITIALIZATION & UPDATE LOGIC ---
 
 func initialModel() model {
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	if encrypted := dataFileEncrypted(); encrypted || cfg.EncryptData {
+		// A plaintext file about to be encrypted needs a new passphrase.
+		dataPassphrase, err = readPassphrase(!encrypted)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	var accentWarning string
+	if cfg.Accent != "" {
+		if err := applyAccent(cfg.Accent); err != nil {
+			accentWarning = fmt.Sprintf("%v; using the default colors", err)
+		}
+	}
+
+	seed := shouldSeedWelcome(cfg)
 	loadedProjects, err := loadProjects()
 	if err != nil {
 		fmt.Printf("Error loading projects: %v\n", err)
 		os.Exit(1)
 	}
+	if seed {
+		welcome := welcomeProject()
+		welcome.Name = uniqueProjectName(loadedProjects, welcome.Name)
+		loadedProjects = append(loadedProjects, welcome)
+	}
 
 	items := make([]list.Item, len(loadedProjects))
 	for i, project := range loadedProjects {
-		items[i] = projectItem{name: project.Name, colorCount: len(project.Colors), urlCount: len(project.Urls)}
+		items[i] = projectItem{name: project.Name, colorCount: len(project.Colors), urlCount: len(project.Urls), scratch: project.Scratch}
 	}
 
-	delegate := newCustomDelegate()
-	l := list.New(items, delegate, 0, 0)
+	l := list.New(items, projectDelegate(cfg.CompactList, cfg.ShowDescriptions), 0, 0)
 	l.Title = "🪩 DIAMONDS "
 	l.SetShowStatusBar(false)
-	l.SetFilteringEnabled(false)
 	l.Styles.Title = headerStyle.MarginTop(0).PaddingTop(1)
 	l.Styles.HelpStyle = helpStyle
 	l.SetShowHelp(false)
-
-	return model{
-		projectList: l,
-		projects:    loadedProjects,
-		currentView: ProjectListView,
+	// The list's own q/esc quit returns tea.Quit directly, skipping the
+	// unsaved changes and scratch prompts; updateProjectList routes quitting
+	// through m.quit instead.
+	l.DisableQuitKeybindings()
+
+	m := model{
+		message:      appConfigDirWarning,
+		projectList:  l,
+		projects:     loadedProjects,
+		currentView:  ProjectListView,
+		config:       cfg,
+		expandedURL:  -1,
+		editingIndex: -1,
+		compactList:  cfg.CompactList,
+		dataHash:     dataFileHash(),
+	}
+	m.rememberProjects()
+	if m.projectSort() != projectSortManual {
+		m.updateProjectListItems()
 	}
+	if accentWarning != "" {
+		m.message = accentWarning
+	}
+	if seed {
+		m.saveProjects()
+		m.config.Seeded = true
+		if err := setConfigValue("seeded", true); err != nil {
+			m.message = fmt.Sprintf("Error updating config: %v", err)
+		}
+	}
+	if cfg.ManualSaveOnRemovable {
+		if path, err := getDataFilePath(); err == nil {
+			m.manualSave = onRemovableOrNetworkDrive(path)
+		}
+	}
+	if cfg.WatchDataFile {
+		if m.dataChanges, err = watchDataFile(); err != nil {
+			m.message = fmt.Sprintf("Error watching data file: %v", err)
+		}
+	}
+	return m
 }
 
 func (m *model) updateProjectListItems() {
 	items := make([]list.Item, len(m.projects))
-	for i, project := range m.projects {
-		items[i] = projectItem{name: project.Name, colorCount: len(project.Colors), urlCount: len(project.Urls)}
+	for pos, i := range projectOrder(m.projects, m.projectSort()) {
+		project := m.projects[i]
+		items[pos] = projectItem{name: project.Name, colorCount: len(project.Colors), urlCount: len(project.Urls), scratch: project.Scratch}
 	}
 	m.projectList.SetItems(items)
+	// Keep the selection on a real item when the list shrinks, e.g. after
+	// deleting the last project.
+	if n := len(m.projectList.VisibleItems()); n > 0 && m.projectList.Index() >= n {
+		m.projectList.Select(n - 1)
+	}
+}
+
+// selectedProjectIndex returns the index in m.projects of the project
+// highlighted in the project list, or -1 when nothing is highlighted. The
+// list's own Index counts only the items its filter shows.
+func (m *model) selectedProjectIndex() int {
+	item, ok := m.projectList.SelectedItem().(projectItem)
+	if !ok {
+		return -1
+	}
+	for i, p := range m.projects {
+		if p.Name == item.name {
+			return i
+		}
+	}
+	return -1
+}
+
+// selectProjectItem highlights project i in the project list, clearing any
+// filter first so i lines up with the list's items.
+func (m *model) selectProjectItem(i int) {
+	if m.projectList.FilterState() != list.Unfiltered {
+		m.projectList.ResetFilter()
+	}
+	for pos, item := range m.projectList.Items() {
+		if item.(projectItem).name == m.projects[i].Name {
+			m.projectList.Select(pos)
+			return
+		}
+	}
 }
 
 func (m *model) Init() tea.Cmd {
-	return nil
+	if m.dataChanges != nil {
+		return tea.Batch(m.scheduleBackup(), waitForDataChange(m.dataChanges))
+	}
+	return m.scheduleBackup()
 }
 
 func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Key presses clear the message, so one set while handling a key is new
+	// even if it repeats the old text.
+	message := m.message
+	if _, ok := msg.(tea.KeyMsg); ok {
+		message = ""
+	}
+	model, cmd := m.update(msg)
+	if clearCmd := m.scheduleMessageClear(message); clearCmd != nil {
+		cmd = tea.Batch(cmd, clearCmd)
+	}
+	// Saves only flag that a sync is due; start it once the message is handled.
+	if syncCmd := m.gitSyncCmd(); syncCmd != nil {
+		cmd = tea.Batch(cmd, syncCmd)
+	}
+	return model, cmd
+}
+
+func (m *model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Clear the message on any key press
 	if _, ok := msg.(tea.KeyMsg); ok {
 		m.message = ""
@@ -260,9 +792,66 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if msg, ok := msg.(tea.WindowSizeMsg); ok {
 		h, v := docStyle.GetHorizontalPadding(), docStyle.GetVerticalPadding()
 		m.projectList.SetSize(msg.Width-h, msg.Height-v)
+		m.width = msg.Width
+		m.height = msg.Height
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok && m.showHelp {
+		return m.updateHelp(msg)
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "F" && m.hasFocusMode() {
+		m.focusMode = !m.focusMode
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok && m.isListView() {
+		if msg.String() == "?" {
+			m.showHelp = true
+			return m, nil
+		}
+		if msg.String() == "ctrl+r" {
+			m.reloadProjects()
+			return m, nil
+		}
+		if msg.String() == "ctrl+e" {
+			m.copyViewAsText()
+			return m, nil
+		}
+		if msg.String() == "ctrl+s" {
+			m.writeProjects()
+			if !m.unsaved {
+				m.message = "Saved"
+			}
+			return m, nil
+		}
+		m.reloadArmed = false
 	}
 
 	switch msg := msg.(type) {
+	case undoExpiredMsg:
+		m.expireUndo(msg.id)
+		return m, nil
+	case clearMessageMsg:
+		if msg.id == m.messageSeq {
+			m.message = ""
+		}
+		return m, nil
+	case editorFinishedMsg:
+		m.applyEditedProject(msg)
+		return m, nil
+	case gitSyncMsg:
+		return m, m.finishGitSync(msg)
+	case dataFileChangedMsg:
+		return m, m.handleDataFileChanged()
+	case slideTickMsg:
+		return m, m.handleSlideTick(msg)
+	case backupTickMsg:
+		// Skip idle intervals so unchanged copies don't prune older backups.
+		if m.changesSinceBackup > 0 || m.lastBackup.IsZero() {
+			m.runBackup()
+		}
+		return m, m.scheduleBackup()
 	case tea.KeyMsg:
 		switch m.currentView {
 		case ProjectListView:
@@ -281,42 +870,183 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateAddUrl(msg)
 		case ConfirmDeleteProjectView:
 			return m.updateConfirmDeleteProject(msg)
+		case ImportView:
+			return m.updateImport(msg)
+		case RecolorView:
+			return m.updateRecolor(msg)
+		case PinnedNoteView:
+			return m.updatePinnedNote(msg)
+		case InfoView:
+			return m.updateInfo(msg)
+		case ExportMenuView:
+			return m.updateExportMenu(msg)
+		case SplitSelectView:
+			return m.updateSplitSelect(msg)
+		case SplitNameView:
+			return m.updateSplitName(msg)
+		case BulkUrlView:
+			return m.updateBulkUrl(msg)
+		case ScratchQuitView:
+			return m.updateScratchQuit(msg)
+		case UnsavedQuitView:
+			return m.updateUnsavedQuit(msg)
+		case RolePickerView:
+			return m.updateRolePicker(msg)
+		case SlideshowView:
+			return m.updateSlideshow(msg)
+		case ColorGroupView:
+			return m.updateColorGroup(msg)
+		case MoveColorView:
+			return m.updateMoveColor(msg)
+		case SearchView:
+			return m.updateSearch(msg)
+		case ContrastView:
+			return m.updateContrast(msg)
+		case RampView:
+			return m.updateRamp(msg)
 		}
 	}
 	return m, nil
 }
 
 func (m *model) updateProjectList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// While a filter is being typed every key but ctrl+c goes to it.
+	if m.projectList.FilterState() == list.Filtering {
+		if msg.String() == "ctrl+c" {
+			return m.quit()
+		}
+		var cmd tea.Cmd
+		m.projectList, cmd = m.projectList.Update(msg)
+		return m, cmd
+	}
+
 	switch msg.String() {
-	case "ctrl+c", "q":
-		return m, tea.Quit
+	case "ctrl+c":
+		return m.quit()
+	case "q":
+		if m.config.QuitOnQ {
+			return m.quit()
+		}
+		return m, nil
+	case "esc":
+		// esc clears an applied filter first; only then does it quit.
+		if m.projectList.FilterState() == list.FilterApplied {
+			m.projectList.ResetFilter()
+			return m, nil
+		}
+		if m.config.QuitOnQ {
+			return m.quit()
+		}
+		return m, nil
 	case "enter":
-		selectedItem, ok := m.projectList.SelectedItem().(projectItem)
-		if ok {
-			for i, p := range m.projects {
-				if p.Name == selectedItem.name {
-					m.selectedProject = i
-					m.currentView = ProjectMenuView
-					m.cursor = 0
-					break
-				}
-			}
+		if i := m.selectedProjectIndex(); i >= 0 {
+			m.selectedProject = i
+			m.currentView = ProjectMenuView
+			m.cursor = 0
 		}
 		return m, nil
 	case "n":
 		m.currentView = AddProjectView
 		m.inputBuffer = ""
+		m.editingIndex = -1
+		return m, nil
+	case "N":
+		m.selectedProject = m.newScratchProject()
+		m.selectProjectItem(m.selectedProject)
+		m.currentView = ProjectMenuView
+		m.cursor = 0
+		return m, nil
+	case "i":
+		m.currentView = ImportView
+		m.inputBuffer = ""
+		return m, nil
+	case "y":
+		if selectedItem, ok := m.projectList.SelectedItem().(projectItem); ok {
+			m.copyProjectName(selectedItem.name)
+		}
+		return m, nil
+	case "Y":
+		if i := m.selectedProjectIndex(); i >= 0 {
+			m.copyPrimaryColor(i)
+		}
+		return m, nil
+	case "I":
+		m.currentView = InfoView
+		return m, nil
+	case "s":
+		m.startSearch()
+		return m, nil
+	case "p":
+		if len(m.projects) > 0 {
+			m.startSlideshow()
+		}
+		return m, nil
+	case "c":
+		m.compactList = !m.compactList
+		m.projectList.SetDelegate(projectDelegate(m.compactList, m.config.ShowDescriptions))
+		return m, nil
+	case "t":
+		m.config.ShowDescriptions = !m.config.ShowDescriptions
+		m.projectList.SetDelegate(projectDelegate(m.compactList, m.config.ShowDescriptions))
+		if err := setConfigValue("showDescriptions", m.config.ShowDescriptions); err != nil {
+			m.message = fmt.Sprintf("Error saving preference: %v", err)
+		}
 		return m, nil
 	case "d":
-		selectedItem, ok := m.projectList.SelectedItem().(projectItem)
-		if ok {
-			for i, p := range m.projects {
-				if p.Name == selectedItem.name {
-					m.selectedProject = i
-					m.currentView = ConfirmDeleteProjectView
-					break
-				}
-			}
+		if i := m.selectedProjectIndex(); i >= 0 {
+			m.selectedProject = i
+			m.currentView = ConfirmDeleteProjectView
+		}
+		return m, nil
+	case "z":
+		m.applyUndo()
+		return m, nil
+	case "o":
+		i := m.selectedProjectIndex()
+		m.config.ProjectSort = projectSortNames[m.projectSort().next()]
+		if err := setConfigValue("projectSort", m.config.ProjectSort); err != nil {
+			m.message = fmt.Sprintf("Error saving preference: %v", err)
+		} else {
+			m.message = fmt.Sprintf("Sorted by: %s", m.projectSort())
+		}
+		m.updateProjectListItems()
+		if i >= 0 && m.projectList.FilterState() == list.Unfiltered {
+			m.selectProjectItem(i)
+		}
+		return m, nil
+	case "T", "B":
+		if m.projectList.FilterState() != list.Unfiltered {
+			m.message = "Clear the filter (esc) to reorder projects"
+			return m, nil
+		}
+		if m.projectSort() != projectSortManual {
+			m.message = "Switch to manual sort (o) to reorder projects"
+			return m, nil
+		}
+		if to, ok := moveToEnd(m.projects, m.projectList.Index(), msg.String() == "T"); ok {
+			m.updateProjectListItems()
+			m.projectList.Select(to)
+			m.saveProjects()
+		}
+		return m, nil
+	case "shift+up", "K", "shift+down", "J":
+		if m.projectList.FilterState() != list.Unfiltered {
+			m.message = "Clear the filter (esc) to reorder projects"
+			return m, nil
+		}
+		if m.projectSort() != projectSortManual {
+			m.message = "Switch to manual sort (o) to reorder projects"
+			return m, nil
+		}
+		from := m.projectList.Index()
+		to := from + 1
+		if msg.String() == "shift+up" || msg.String() == "K" {
+			to = from - 1
+		}
+		if moveItem(m.projects, from, to) {
+			m.updateProjectListItems()
+			m.projectList.Select(to)
+			m.saveProjects()
 		}
 		return m, nil
 	}
@@ -325,134 +1055,994 @@ func (m *model) updateProjectList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// editInput applies a key press to a single-line text input buffer.
+func editInput(buffer string, msg tea.KeyMsg) string {
+	switch msg.String() {
+	case "backspace":
+		if len(buffer) > 0 {
+			return buffer[:len(buffer)-1]
+		}
+	case " ":
+		return buffer + " "
+	default:
+		if msg.Type == tea.KeyRunes {
+			return buffer + string(msg.Runes)
+		}
+	}
+	return buffer
+}
+
+// isListView reports whether the current view is one of the browsing views,
+// as opposed to forms and prompts. Inline renames and filter typing count as
+// forms.
+func (m *model) isListView() bool {
+	if m.renaming || m.urlFiltering || m.projectList.FilterState() == list.Filtering {
+		return false
+	}
+	switch m.currentView {
+	case ProjectListView, ProjectMenuView, ColorListView, UrlListView:
+		return true
+	}
+	return false
+}
+
+// hasFocusMode reports whether the current view can toggle focus mode. Views
+// with text input need every key for typing.
+func (m *model) hasFocusMode() bool {
+	return m.isListView()
+}
+
+// moveCursor moves the cursor by delta within a list of n items. Past either
+// end it stops, or wraps around when the wrapNavigation option is on.
+func (m *model) moveCursor(delta, n int) {
+	if n == 0 {
+		return
+	}
+	next := m.cursor + delta
+	switch {
+	case next < 0 && m.config.WrapNavigation:
+		next = n - 1
+	case next < 0:
+		next = 0
+	case next >= n && m.config.WrapNavigation:
+		next = 0
+	case next >= n:
+		next = n - 1
+	}
+	m.cursor = next
+}
+
 func (m *model) updateProjectMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "ctrl+c", "q":
-		return m, tea.Quit
+	case "ctrl+c":
+		return m.quit()
+	case "q":
+		if m.config.QuitOnQ {
+			return m.quit()
+		}
+		return m, nil
 	case "esc":
 		m.currentView = ProjectListView
 	case "up", "k":
-		if m.cursor > 0 {
-			m.cursor--
-		}
+		m.moveCursor(-1, 2)
 	case "down", "j":
-		if m.cursor < 1 {
-			m.cursor++
-		}
+		m.moveCursor(1, 2)
 	case "enter":
 		if m.cursor == 0 {
 			m.currentView = ColorListView
 		} else {
 			m.currentView = UrlListView
+			m.clearUrlFilter()
 		}
 		m.cursor = 0
+	case "y":
+		m.copyProjectName(m.projects[m.selectedProject].Name)
+	case "p":
+		m.currentView = PinnedNoteView
+		m.inputBuffer = m.projects[m.selectedProject].Pinned
+	case "u":
+		m.cycleURLFormat()
+	case "s":
+		m.currentView = SplitSelectView
+		m.splitSelected = map[int]bool{}
+		m.cursor = 0
+	case "r":
+		m.currentView = AddProjectView
+		m.inputBuffer = m.projects[m.selectedProject].Name
+		m.editingIndex = m.selectedProject
+	case "E":
+		return m, m.editProjectInEditor()
+	case "c":
+		m.selectedProject = m.duplicateProject(m.selectedProject, m.projects[m.selectedProject].Name+" (copy)")
+		m.updateProjectListItems()
+		m.saveProjects()
+		m.selectProjectItem(m.selectedProject)
+		m.cursor = 0
+		m.message = fmt.Sprintf("Created '%s'; you're now in the copy", m.projects[m.selectedProject].Name)
+	case "b":
+		project := m.projects[m.selectedProject]
+		if err := clipboard.WriteAll(formatProjectBundle(project, m.config)); err != nil {
+			m.message = fmt.Sprintf("Error copying to clipboard: %v", err)
+		} else {
+			m.message = fmt.Sprintf(" Copied '%s' bundle (%d colors, %d links) to clipboard! ", project.Name, len(project.Colors), len(project.Urls))
+		}
 	}
 	return m, nil
 }
 
-func (m *model) updateColorList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+// urlFormat is the selected project's URL copy format, falling back to the
+// defaultURLFormat option.
+func (m *model) urlFormat() urlFormat {
+	if f, ok := parseURLFormat(m.projects[m.selectedProject].URLFormat); ok {
+		return f
+	}
+	f, _ := parseURLFormat(m.config.DefaultURLFormat)
+	return f
+}
+
+// urlFormatLabel names the active URL copy format for footers and menus.
+func (m *model) urlFormatLabel() string {
+	if m.projects[m.selectedProject].URLFormat == "" {
+		return m.urlFormat().String() + " (default)"
+	}
+	return m.urlFormat().String()
+}
+
+// cycleURLFormat advances the selected project's URL copy format and saves it.
+func (m *model) cycleURLFormat() {
+	project := &m.projects[m.selectedProject]
+	project.URLFormat = nextProjectURLFormat(project.URLFormat)
+	m.saveProjects()
+	m.message = fmt.Sprintf("URL copy format: %s", m.urlFormatLabel())
+}
+
+func (m *model) updateSplitSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	project := m.projects[m.selectedProject]
+	total := len(project.Colors) + len(project.Urls)
+
 	switch msg.String() {
-	case "ctrl+c", "q":
-		return m, tea.Quit
+	case "ctrl+c":
+		return m.quit()
 	case "esc":
 		m.currentView = ProjectMenuView
+		m.cursor = 0
 	case "up", "k":
-		if m.cursor > 0 {
-			m.cursor--
-		}
+		m.moveCursor(-1, total)
 	case "down", "j":
-		if m.cursor < len(m.projects[m.selectedProject].Colors)-1 {
-			m.cursor++
+		m.moveCursor(1, total)
+	case " ":
+		if total > 0 {
+			m.splitSelected[m.cursor] = !m.splitSelected[m.cursor]
 		}
 	case "enter":
-				if len(m.projects[m.selectedProject].Colors) > 0 {
-			color := m.projects[m.selectedProject].Colors[m.cursor]
-			err := clipboard.WriteAll(color)
-			if err != nil {
-				m.message = fmt.Sprintf("Error copying to clipboard: %v", err)
-			} else {
-				m.message = fmt.Sprintf(" Copied %s to clipboard! ", color)
+		selected := 0
+		for _, on := range m.splitSelected {
+			if on {
+				selected++
 			}
 		}
+		if selected == 0 {
+			m.message = "Select at least one color or URL to split off"
+			return m, nil
+		}
+		m.currentView = SplitNameView
+		m.inputBuffer = ""
+	}
+	return m, nil
+}
+
+func (m *model) updateSplitName(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc":
+		m.currentView = SplitSelectView
+		m.inputBuffer = ""
+	case "enter":
+		name := strings.TrimSpace(m.inputBuffer)
+		if name == "" {
+			return m, nil
+		}
+		kept, split := splitProject(m.projects[m.selectedProject], m.splitSelected)
+		split.Name = uniqueProjectName(m.projects, name)
+		m.projects[m.selectedProject] = kept
+		m.projects = append(m.projects, split)
+		m.updateProjectListItems()
+		m.saveProjects()
+		m.message = fmt.Sprintf("Moved %d colors and %d URLs into '%s'", len(split.Colors), len(split.Urls), split.Name)
+		m.currentView = ProjectMenuView
+		m.cursor = 0
+		m.inputBuffer = ""
+	default:
+		m.inputBuffer = editInput(m.inputBuffer, msg)
+	}
+	return m, nil
+}
+
+func (m *model) copyProjectName(name string) {
+	if err := clipboard.WriteAll(name); err != nil {
+		m.message = fmt.Sprintf("Error copying to clipboard: %v", err)
+	} else {
+		m.message = fmt.Sprintf(" Copied %s to clipboard! ", name)
+	}
+}
+
+func (m *model) updateColorList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.renaming {
+		return m.updateInlineRename(msg)
+	}
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "q":
+		if m.config.QuitOnQ {
+			return m.quit()
+		}
+		return m, nil
+	case "esc":
+		m.currentView = ProjectMenuView
+		m.colorSelection = nil
+	case "up", "k":
+		if m.colorGrid {
+			m.moveCursor(-m.colorGridColumns(), len(m.colorRows()))
+		} else {
+			m.moveCursor(-1, len(m.colorRows()))
+		}
+	case "down", "j":
+		if m.colorGrid {
+			m.moveCursor(m.colorGridColumns(), len(m.colorRows()))
+		} else {
+			m.moveCursor(1, len(m.colorRows()))
+		}
+	case "left", "h":
+		if m.colorGrid {
+			m.moveCursor(-1, len(m.colorRows()))
+		}
+	case "right", "l":
+		if m.colorGrid {
+			m.moveCursor(1, len(m.colorRows()))
+		}
+	case "g":
+		m.colorGrid = !m.colorGrid
+	case "tab":
+		m.toggleColorGroup()
+	case "S":
+		m.startColorGroup()
+	case "v":
+		m.config.NameFirst = !m.config.NameFirst
+		if err := setConfigValue("nameFirst", m.config.NameFirst); err != nil {
+			m.message = fmt.Sprintf("Error saving preference: %v", err)
+		}
+	case "enter", "alt+enter":
+		if index := m.selectedColorIndex(); index >= 0 {
+			if m.copyColor(m.projects[m.selectedProject].Colors[index].Hex) {
+				m.projects[m.selectedProject].Colors[index].Copies++
+				m.saveProjects()
+				if msg.String() == "alt+enter" {
+					return m.copyAndQuit()
+				}
+			}
+		} else if len(m.colorRows()) > 0 {
+			// enter on a collapsed section opens it.
+			m.toggleColorGroup()
+		}
 	case "d":
-		if len(m.projects[m.selectedProject].Colors) > 0 {
-			deletedColor := m.projects[m.selectedProject].Colors[m.cursor]
-			m.projects[m.selectedProject].Colors = append(m.projects[m.selectedProject].Colors[:m.cursor], m.projects[m.selectedProject].Colors[m.cursor+1:]...)
+		if index := m.selectedColorIndex(); index >= 0 {
+			m.beginUndo()
+			m.colorSelection = nil
+			deletedColor := m.projects[m.selectedProject].Colors[index].Hex
+			m.projects[m.selectedProject].Colors = append(m.projects[m.selectedProject].Colors[:index], m.projects[m.selectedProject].Colors[index+1:]...)
 			m.updateProjectListItems()
 			m.saveProjects()
-			m.message = fmt.Sprintf("Deleted color %s", deletedColor)
 
-			if m.cursor > 0 && m.cursor >= len(m.projects[m.selectedProject].Colors) {
+			if m.cursor > 0 && m.cursor >= len(m.colorRows()) {
 				m.cursor--
 			}
+			return m, m.offerUndo(fmt.Sprintf("Deleted %s", displayHex(deletedColor, m.config)))
 		}
+	case "z":
+		m.applyUndo()
 	case "n":
 		m.currentView = AddColorView
 		m.inputBuffer = ""
+		m.colorNameBuffer = ""
+		m.focusedField = 0
+		m.editingIndex = -1
+	case "e":
+		if index := m.selectedColorIndex(); index >= 0 {
+			color := m.projects[m.selectedProject].Colors[index]
+			m.currentView = AddColorView
+			m.inputBuffer = color.Hex
+			if color.Source != "" {
+				m.inputBuffer = color.Source
+			}
+			m.colorNameBuffer = color.Name
+			m.focusedField = 0
+			m.editingIndex = index
+		}
+	case "x":
+		m.currentView = ExportMenuView
+		m.exportCursor = 0
+	case "f":
+		m.colorFormat = m.colorFormat.next()
+		m.message = fmt.Sprintf("Copy format: %s", m.colorFormat)
+	case " ":
+		if index := m.selectedColorIndex(); index >= 0 {
+			if pos := slices.Index(m.colorSelection, index); pos >= 0 {
+				m.colorSelection = slices.Delete(m.colorSelection, pos, pos+1)
+			} else {
+				m.colorSelection = append(m.colorSelection, index)
+			}
+		}
+	case "G":
+		m.copyGradient()
+	case "r":
+		if index := m.selectedColorIndex(); index >= 0 {
+			m.startRename(index, m.projects[m.selectedProject].Colors[index].Name)
+		}
+	case "T", "B":
+		if m.colorSort() != colorSortManual {
+			m.message = "Switch to manual sort (o) to reorder colors"
+			break
+		}
+		m.moveColorInGroup(msg.String())
+	case "shift+up", "K", "shift+down", "J":
+		if m.colorSort() != colorSortManual {
+			m.message = "Switch to manual sort (o) to reorder colors"
+			break
+		}
+		m.moveColorInGroup(msg.String())
+	case "m":
+		m.startMoveColor()
+	case "c":
+		m.compareColor()
+	case "t":
+		m.startRamp()
+	case "R":
+		if index := m.selectedColorIndex(); index >= 0 {
+			m.currentView = RolePickerView
+			m.roleCursor = max(slices.Index(roleOptions(), m.projects[m.selectedProject].Colors[index].Role), 0)
+		}
+	case "i":
+		m.showCSSNames = !m.showCSSNames
+		if m.showCSSNames {
+			m.message = "Showing the nearest CSS color names (* marks approximate matches)"
+		}
+	case "w":
+		m.showContrast = !m.showContrast
+		if m.showContrast {
+			m.message = fmt.Sprintf("Contrast against %s", displayHex(m.contrastBackground(), m.config))
+		}
+	case "W":
+		if index := m.selectedColorIndex(); index >= 0 {
+			project := &m.projects[m.selectedProject]
+			hex := project.Colors[index].Hex
+			if project.Background == hex {
+				project.Background = ""
+			} else {
+				project.Background = hex
+			}
+			m.saveProjects()
+			m.showContrast = true
+			m.message = fmt.Sprintf("Contrast against %s", displayHex(m.contrastBackground(), m.config))
+		}
+	case "o":
+		project := &m.projects[m.selectedProject]
+		project.ColorSort = colorSortNames[m.colorSort().next()]
+		m.saveProjects()
+		m.cursor = 0
+		m.message = fmt.Sprintf("Sorted by: %s", m.colorSort())
+	case "O":
+		// Make the displayed order the stored one, so it survives switching
+		// back to manual sort and can be fine-tuned with the move keys.
+		if m.colorSort() == colorSortManual {
+			m.message = "Already in manual order; press o to pick a sort first"
+			break
+		}
+		project := &m.projects[m.selectedProject]
+		sorted := m.colorSort()
+		colors := make([]Color, 0, len(project.Colors))
+		for _, i := range colorOrder(project.Colors, sorted) {
+			colors = append(colors, project.Colors[i])
+		}
+		project.Colors = colors
+		project.ColorSort = colorSortNames[colorSortManual]
+		m.colorSelection = nil
+		m.saveProjects()
+		m.cursor = 0
+		m.message = fmt.Sprintf("Saved the %s order as the manual order", sorted)
+	case "H":
+		if len(m.projects[m.selectedProject].Colors) > 0 {
+			m.currentView = RecolorView
+			m.hueShift = 0
+			m.lightShift = 0
+		}
 	}
 	return m, nil
 }
 
-func (m *model) updateUrlList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+// contrastBackground is the color contrast badges are measured against: the
+// project's background, then the contrastBackground option, then black or
+// white to match the terminal.
+func (m *model) contrastBackground() string {
+	if bg := m.projects[m.selectedProject].Background; isHexColor(bg) {
+		return bg
+	}
+	if isHexColor(m.config.ContrastBackground) {
+		return m.config.ContrastBackground
+	}
+	if lipgloss.HasDarkBackground() {
+		return "#000000"
+	}
+	return "#ffffff"
+}
+
+// contrastBadge labels how hex does as text on the contrast background.
+func (m *model) contrastBadge(hex string) string {
+	ratio, ok := contrastRatio(hex, m.contrastBackground())
+	if !ok {
+		return ""
+	}
+	return subtleStyle.Render(fmt.Sprintf("%s %.1f:1", wcagLevel(ratio), ratio))
+}
+
+// colorSort is the selected project's color sort.
+func (m *model) colorSort() colorSort {
+	return parseColorSort(m.projects[m.selectedProject].ColorSort)
+}
+
+// urlSort is the selected project's URL sort.
+func (m *model) urlSort() urlSort {
+	return parseURLSort(m.projects[m.selectedProject].URLSort)
+}
+
+// copyAndQuit quits right after a successful copy. Saves are already written
+// by then; a pending git sync is run before exiting rather than in the
+// background, where quitting would cut it short.
+func (m *model) copyAndQuit() (tea.Model, tea.Cmd) {
+	if m.manualSave && m.unsaved {
+		m.writeProjects()
+	}
+	if m.unsaved {
+		// Let quit ask about the changes that didn't save.
+		return m.quit()
+	}
+	if m.gitSyncPending && !m.gitSyncing {
+		m.gitSyncPending = false
+		if err := gitSyncData(m.config.GitPush); err != nil {
+			m.message = fmt.Sprintf("Git sync: %v", err)
+			return m, nil
+		}
+	}
+	return m.quit()
+}
+
+// copyPrimaryColor copies the project's primary color without opening it: the
+// color with the primary role, or else its first color.
+func (m *model) copyPrimaryColor(projectIndex int) {
+	project := &m.projects[projectIndex]
+	if len(project.Colors) == 0 {
+		m.message = fmt.Sprintf("'%s' has no colors", project.Name)
+		return
+	}
+	index := max(roleHolder(project.Colors, "primary"), 0)
+	if m.copyColor(project.Colors[index].Hex) {
+		project.Colors[index].Copies++
+		m.saveProjects()
+		m.message = fmt.Sprintf(" Copied %s from '%s' to clipboard! ", displayHex(project.Colors[index].Hex, m.config), project.Name)
+	}
+}
+
+// selectedColorIndex maps the color list cursor to an index into the
+// selected project's colors, which differ when the list is sorted or
+// sectioned. It is -1 on a collapsed section or in an empty list.
+func (m *model) selectedColorIndex() int {
+	rows := m.colorRows()
+	if m.cursor < 0 || m.cursor >= len(rows) {
+		return -1
+	}
+	return rows[m.cursor].index
+}
+
+// copyGradient copies a CSS linear-gradient through the selected colors, in
+// the order they were selected.
+func (m *model) copyGradient() {
+	if len(m.colorSelection) < 2 {
+		m.message = "Select at least two colors with space to copy a gradient"
+		return
+	}
+	stops := make([]string, len(m.colorSelection))
+	for i, index := range m.colorSelection {
+		stops[i] = displayHex(m.projects[m.selectedProject].Colors[index].Hex, m.config)
+	}
+	gradient := fmt.Sprintf("linear-gradient(%s)", strings.Join(stops, ", "))
+	if err := clipboard.WriteAll(gradient); err != nil {
+		m.message = fmt.Sprintf("Error copying to clipboard: %v", err)
+		return
+	}
+	m.message = fmt.Sprintf(" Copied %s to clipboard! ", gradient)
+}
+
+// colorCursorFor returns the color list cursor position showing the color at
+// index.
+func (m *model) colorCursorFor(index int) int {
+	for pos, row := range m.colorRows() {
+		if row.index == index {
+			return pos
+		}
+	}
+	return 0
+}
+
+// copyColor writes hex to the clipboard in the active copy format and reports
+// whether it succeeded.
+func (m *model) copyColor(hex string) bool {
+	text, err := formatColor(hex, m.colorFormat, m.config)
+	if err != nil {
+		m.message = fmt.Sprintf("Error formatting color: %v", err)
+		return false
+	}
+	if err := clipboard.WriteAll(text); err != nil {
+		m.message = fmt.Sprintf("Error copying to clipboard: %v", err)
+		return false
+	}
+
+	// Data URIs are far too long to echo back in the status line.
+	switch m.colorFormat {
+	case formatHex:
+		m.message = fmt.Sprintf(" Copied %s to clipboard! ", text)
+	case formatDataURI:
+		m.message = fmt.Sprintf(" Copied %dpx PNG data URI for %s to clipboard! ", m.config.SwatchSize, displayHex(hex, m.config))
+	default:
+		m.message = fmt.Sprintf(" Copied %s to clipboard as %s! ", text, m.colorFormat)
+	}
+	return true
+}
+
+// exportWidth is the width exports lay out to: the exportWidth option when set,
+// otherwise the current terminal width.
+func (m *model) exportWidth() int {
+	if m.config.ExportWidth > 0 {
+		return m.config.ExportWidth
+	}
+	if m.width > 0 {
+		return m.width
+	}
+	return 80
+}
+
+func (m *model) updateExportMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "ctrl+c", "q":
-		return m, tea.Quit
+	case "ctrl+c":
+		return m.quit()
 	case "esc":
-		m.currentView = ProjectMenuView
+		m.currentView = ColorListView
 	case "up", "k":
-		if m.cursor > 0 {
-			m.cursor--
+		if m.exportCursor > 0 {
+			m.exportCursor--
 		}
 	case "down", "j":
-		if m.cursor < len(m.projects[m.selectedProject].Urls)-1 {
-			m.cursor++
+		if m.exportCursor < len(exporters)-1 {
+			m.exportCursor++
 		}
 	case "enter":
-		if len(m.projects[m.selectedProject].Urls) > 0 {
-			url := m.projects[m.selectedProject].Urls[m.cursor].URL
+		exp := exporters[m.exportCursor]
+		text := exp.render(m.projects[m.selectedProject], exportOptions{width: m.exportWidth(), cfg: m.config})
+		if err := clipboard.WriteAll(text); err != nil {
+			m.message = fmt.Sprintf("Error copying to clipboard: %v", err)
+		} else {
+			m.message = fmt.Sprintf(" Copied %s export to clipboard! ", exp.name)
+		}
+		m.currentView = ColorListView
+	}
+	return m, nil
+}
+
+// duplicateProject appends a deep copy of the project at index under the given
+// name and returns the index of the copy.
+func (m *model) duplicateProject(index int, name string) int {
+	clone := cloneProject(m.projects[index])
+	clone.Name = uniqueProjectName(m.projects, name)
+	clone.CreatedAt, clone.UpdatedAt = time.Time{}, time.Time{}
+	m.projects = append(m.projects, clone)
+	return len(m.projects) - 1
+}
+
+// shiftedColors returns the selected project's colors with the pending
+// RecolorView hue and lightness shift applied.
+func (m *model) shiftedColors() []Color {
+	colors := append([]Color{}, m.projects[m.selectedProject].Colors...)
+	for i := range colors {
+		colors[i].Hex = shiftHex(colors[i].Hex, m.hueShift, m.lightShift/100)
+	}
+	return colors
+}
+
+func (m *model) updateRecolor(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc":
+		m.currentView = ColorListView
+	case "left", "h":
+		m.hueShift -= 15
+	case "right", "l":
+		m.hueShift += 15
+	case "up", "k":
+		m.lightShift = math.Min(m.lightShift+5, 100)
+	case "down", "j":
+		m.lightShift = math.Max(m.lightShift-5, -100)
+	case "enter":
+		m.projects[m.selectedProject].Colors = m.shiftedColors()
+		m.saveProjects()
+		m.message = fmt.Sprintf("Recolored %d colors", len(m.projects[m.selectedProject].Colors))
+		m.currentView = ColorListView
+	case "c":
+		colors := m.shiftedColors()
+		name := fmt.Sprintf("%s (hue %+.0f°, light %+.0f%%)", m.projects[m.selectedProject].Name, m.hueShift, m.lightShift)
+		m.selectedProject = m.duplicateProject(m.selectedProject, name)
+		m.projects[m.selectedProject].Colors = colors
+		m.updateProjectListItems()
+		m.saveProjects()
+		m.message = fmt.Sprintf("Created '%s'", m.projects[m.selectedProject].Name)
+		m.currentView = ColorListView
+		m.cursor = 0
+	}
+	return m, nil
+}
+
+func (m *model) updateUrlList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.renaming {
+		return m.updateInlineRename(msg)
+	}
+	if m.urlFiltering {
+		return m.updateUrlFilter(msg)
+	}
+	visible := m.visibleURLs()
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "q":
+		if m.config.QuitOnQ {
+			return m.quit()
+		}
+		return m, nil
+	case "esc":
+		if m.urlFilter != "" {
+			m.clearUrlFilter()
+			return m, nil
+		}
+		m.currentView = ProjectMenuView
+	case "/":
+		m.urlFiltering = true
+	case "up", "k":
+		m.moveCursor(-1, len(visible))
+	case "down", "j":
+		m.moveCursor(1, len(visible))
+	case "enter", "alt+enter":
+		if len(visible) > 0 {
+			url := formatURL(m.projects[m.selectedProject].Urls[visible[m.cursor]], m.urlFormat(), m.config)
 			err := clipboard.WriteAll(url)
 			if err != nil {
 				m.message = fmt.Sprintf("Error copying to clipboard: %v", err)
 			} else {
 				m.message = fmt.Sprintf(" Copied %s to clipboard! ", url)
+				if msg.String() == "alt+enter" {
+					return m.copyAndQuit()
+				}
 			}
 		}
 	case "d":
-		if len(m.projects[m.selectedProject].Urls) > 0 {
-			deletedUrl := m.projects[m.selectedProject].Urls[m.cursor].Name
-			m.projects[m.selectedProject].Urls = append(m.projects[m.selectedProject].Urls[:m.cursor], m.projects[m.selectedProject].Urls[m.cursor+1:]...)
+		if len(visible) > 0 {
+			m.beginUndo()
+			index := visible[m.cursor]
+			deletedUrl := m.projects[m.selectedProject].Urls[index].Name
+			m.projects[m.selectedProject].Urls = append(m.projects[m.selectedProject].Urls[:index], m.projects[m.selectedProject].Urls[index+1:]...)
 			m.updateProjectListItems()
 			m.saveProjects()
-			m.message = fmt.Sprintf("Deleted URL '%s'", deletedUrl)
+			m.expandedURL = -1
 
-			if m.cursor > 0 && m.cursor >= len(m.projects[m.selectedProject].Urls) {
+			if m.cursor > 0 && m.cursor >= len(visible)-1 {
 				m.cursor--
 			}
+			return m, m.offerUndo(fmt.Sprintf("Deleted URL '%s'", deletedUrl))
+		}
+	case "z":
+		m.applyUndo()
+	case "n":
+		m.currentView = AddUrlView
+		m.editingIndex = -1
+		m.inputBuffer = ""
+		m.urlNameBuffer = ""
+		m.focusedField = 0
+	case "e":
+		if len(visible) > 0 {
+			index := visible[m.cursor]
+			m.currentView = AddUrlView
+			m.editingIndex = index
+			m.urlNameBuffer = m.projects[m.selectedProject].Urls[index].Name
+			m.inputBuffer = m.projects[m.selectedProject].Urls[index].URL
+			m.focusedField = 0
+		}
+	case "P":
+		m.currentView = BulkUrlView
+		m.inputBuffer = ""
+	case "r":
+		if len(visible) > 0 {
+			index := visible[m.cursor]
+			m.startRename(index, m.projects[m.selectedProject].Urls[index].Name)
+		}
+	case "f":
+		m.cycleURLFormat()
+	case " ":
+		if len(visible) == 0 {
+			break
+		}
+		if m.expandedURL == visible[m.cursor] {
+			m.expandedURL = -1
+		} else {
+			m.expandedURL = visible[m.cursor]
+		}
+	case "o":
+		project := &m.projects[m.selectedProject]
+		project.URLSort = urlSortNames[m.urlSort().next()]
+		m.saveProjects()
+		m.cursor = 0
+		m.expandedURL = -1
+		m.message = fmt.Sprintf("Sorted by: %s", m.urlSort())
+	case "shift+up", "K", "shift+down", "J", "T", "B":
+		if m.urlFilter != "" {
+			m.message = "Clear the filter (esc) to reorder URLs"
+			break
+		}
+		if m.urlSort() != urlSortManual {
+			m.message = "Switch to manual sort (o) to reorder URLs"
+			break
+		}
+		m.moveURL(msg.String())
+	}
+	return m, nil
+}
+
+// moveURL reorders the URL under the cursor for one of the move keys. It
+// assumes manual order with no filter, so the cursor is the URL's index.
+func (m *model) moveURL(key string) {
+	urls := m.projects[m.selectedProject].Urls
+	switch key {
+	case "shift+up", "K":
+		if moveItem(urls, m.cursor, m.cursor-1) {
+			m.cursor--
+		} else {
+			return
+		}
+	case "shift+down", "J":
+		if moveItem(urls, m.cursor, m.cursor+1) {
+			m.cursor++
+		} else {
+			return
+		}
+	case "T", "B":
+		to, ok := moveToEnd(urls, m.cursor, key == "T")
+		if !ok {
+			return
+		}
+		m.cursor = to
+	}
+	m.expandedURL = -1
+	m.saveProjects()
+}
+
+// visibleURLs returns the indexes of the selected project's URLs that match
+// the URL filter, in display order. The URL list cursor indexes into this
+// slice.
+func (m *model) visibleURLs() []int {
+	filter := strings.ToLower(m.urlFilter)
+	urls := m.projects[m.selectedProject].Urls
+	var visible []int
+	for _, i := range urlOrder(urls, m.urlSort()) {
+		u := urls[i]
+		if filter == "" || strings.Contains(strings.ToLower(u.Name), filter) || strings.Contains(strings.ToLower(u.URL), filter) {
+			visible = append(visible, i)
+		}
+	}
+	return visible
+}
+
+// updateUrlFilter handles keys while the URL filter is being typed. enter
+// keeps the filter and returns to the list; esc clears it.
+func (m *model) updateUrlFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc":
+		m.clearUrlFilter()
+	case "enter":
+		m.urlFiltering = false
+	default:
+		m.urlFilter = editInput(m.urlFilter, msg)
+		m.cursor = 0
+		m.expandedURL = -1
+	}
+	return m, nil
+}
+
+func (m *model) clearUrlFilter() {
+	m.urlFilter = ""
+	m.urlFiltering = false
+	m.cursor = 0
+	m.expandedURL = -1
+}
+
+func (m *model) updateAddProject(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc":
+		m.currentView = ProjectListView
+		if m.editingIndex >= 0 {
+			m.currentView = ProjectMenuView
+		}
+		m.inputBuffer = ""
+	case "enter":
+		if m.editingIndex >= 0 {
+			m.renameProject()
+			return m, nil
+		}
+		if name := strings.TrimSpace(m.inputBuffer); name != "" {
+			// The project list finds projects by name, so names stay unique.
+			if uniqueProjectName(m.projects, name) != name {
+				m.message = fmt.Sprintf("A project named '%s' already exists", name)
+				return m, nil
+			}
+			m.projects = append(m.projects, Project{Name: name, Colors: []Color{}, Urls: []namedURL{}})
+			m.updateProjectListItems()
+			m.saveProjects()
+			m.currentView = ProjectListView
+			m.inputBuffer = ""
+		}
+	case "backspace":
+		if len(m.inputBuffer) > 0 {
+			m.inputBuffer = m.inputBuffer[:len(m.inputBuffer)-1]
+		}
+	case " ":
+		m.inputBuffer += " "
+	default:
+		if msg.Type == tea.KeyRunes {
+			m.inputBuffer += string(msg.Runes)
+		}
+	}
+	return m, nil
+}
+
+// renameProject applies the name typed in AddProjectView to the project being
+// edited, refusing empty names and names another project already has.
+func (m *model) renameProject() {
+	name := strings.TrimSpace(m.inputBuffer)
+	if name == "" {
+		m.message = "Project name can't be empty"
+		return
+	}
+	for i, p := range m.projects {
+		if i != m.editingIndex && p.Name == name {
+			m.message = fmt.Sprintf("A project named '%s' already exists", name)
+			return
+		}
+	}
+	m.renameProjectPrint(m.projects[m.editingIndex].Name, name)
+	m.projects[m.editingIndex].Name = name
+	m.updateProjectListItems()
+	m.saveProjects()
+	m.currentView = ProjectMenuView
+	m.inputBuffer = ""
+	m.editingIndex = -1
+}
+
+func (m *model) updateBulkUrl(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc":
+		m.currentView = UrlListView
+		m.inputBuffer = ""
+	case "enter":
+		m.inputBuffer += "\n"
+	case "tab":
+		m.inputBuffer += "\t"
+	case "ctrl+s":
+		urls, skipped := parseURLLines(m.inputBuffer)
+		project := &m.projects[m.selectedProject]
+		added := 0
+		for _, u := range urls {
+			name, err := resolveURLName(project.Urls, -1, u.Name, u.URL, m.config.DuplicateURLNames)
+			if err != nil {
+				skipped++
+				continue
+			}
+			project.Urls = append(project.Urls, namedURL{Name: name, URL: u.URL})
+			added++
+		}
+		if added == 0 {
+			m.message = fmt.Sprintf("No new URLs added (%d skipped)", skipped)
+			return m, nil
+		}
+		m.updateProjectListItems()
+		m.saveProjects()
+		m.clearUrlFilter()
+		m.currentView = UrlListView
+		m.cursor = len(project.Urls) - 1
+		m.inputBuffer = ""
+		m.message = fmt.Sprintf("Added %d URLs (%d skipped)", added, skipped)
+	default:
+		m.inputBuffer = editInput(m.inputBuffer, msg)
+	}
+	return m, nil
+}
+
+func (m *model) updateImport(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc":
+		m.currentView = ProjectListView
+		m.inputBuffer = ""
+	case "enter":
+		if m.inputBuffer != "" {
+			project, skipped, err := importProjectFile(m.inputBuffer)
+			if err != nil {
+				m.message = fmt.Sprintf("Error importing: %v", err)
+				return m, nil
+			}
+			project.Name = uniqueProjectName(m.projects, project.Name)
+			m.projects = append(m.projects, project)
+			m.updateProjectListItems()
+			m.saveProjects()
+			m.currentView = ProjectListView
+			m.inputBuffer = ""
+			m.message = fmt.Sprintf("Imported %d colors into '%s' (%d skipped)", len(project.Colors), project.Name, skipped)
+		}
+	case "backspace":
+		if len(m.inputBuffer) > 0 {
+			m.inputBuffer = m.inputBuffer[:len(m.inputBuffer)-1]
+		}
+	case " ":
+		m.inputBuffer += " "
+	default:
+		if msg.Type == tea.KeyRunes {
+			m.inputBuffer += string(msg.Runes)
+		}
+	}
+	return m, nil
+}
+
+func (m *model) updateInfo(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc", "I":
+		m.currentView = ProjectListView
+	case "e":
+		path, err := m.writeKeybindingReference()
+		if err != nil {
+			m.message = fmt.Sprintf("Error exporting keybindings: %v", err)
+		} else {
+			m.message = fmt.Sprintf("Wrote keybindings to %s", path)
 		}
-	case "n":
-		m.currentView = AddUrlView
-		m.inputBuffer = ""
-		m.urlNameBuffer = ""
-		m.focusedField = 0
 	}
 	return m, nil
 }
 
-func (m *model) updateAddProject(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+func (m *model) updatePinnedNote(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "ctrl+c":
-		return m, tea.Quit
+		return m.quit()
 	case "esc":
-		m.currentView = ProjectListView
+		m.currentView = ProjectMenuView
 		m.inputBuffer = ""
 	case "enter":
-		if m.inputBuffer != "" {
-			m.projects = append(m.projects, Project{Name: m.inputBuffer, Colors: []string{}, Urls: []namedURL{}})
-			m.updateProjectListItems()
-			m.saveProjects()
-			m.currentView = ProjectListView
-			m.inputBuffer = ""
-		}
+		m.projects[m.selectedProject].Pinned = strings.TrimSpace(m.inputBuffer)
+		m.saveProjects()
+		m.currentView = ProjectMenuView
+		m.inputBuffer = ""
 	case "backspace":
 		if len(m.inputBuffer) > 0 {
 			m.inputBuffer = m.inputBuffer[:len(m.inputBuffer)-1]
@@ -470,55 +2060,145 @@ func (m *model) updateAddProject(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m *model) updateAddColor(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "ctrl+c":
-		return m, tea.Quit
+		return m.quit()
 	case "esc":
 		m.currentView = ColorListView
 		m.inputBuffer = ""
+		m.colorNameBuffer = ""
+	case "tab", "shift+tab":
+		m.focusedField = 1 - m.focusedField
+	case "ctrl+v":
+		text, err := clipboard.ReadAll()
+		if err != nil {
+			m.message = fmt.Sprintf("Error reading clipboard: %v", err)
+			return m, nil
+		}
+		m.pasteColorInput(text)
 	case "enter":
-		if m.inputBuffer != "" && strings.HasPrefix(m.inputBuffer, "#") && (len(m.inputBuffer) == 7 || len(m.inputBuffer) == 4) {
-			m.projects[m.selectedProject].Colors = append(m.projects[m.selectedProject].Colors, m.inputBuffer)
-			m.updateProjectListItems()
-			m.saveProjects()
-			m.currentView = ColorListView
-			m.cursor = len(m.projects[m.selectedProject].Colors) - 1
-			m.inputBuffer = ""
+		if strings.TrimSpace(m.inputBuffer) == "" {
+			return m, nil
 		}
-	case "backspace":
-		if len(m.inputBuffer) > 0 {
-			m.inputBuffer = m.inputBuffer[:len(m.inputBuffer)-1]
+		hex, source, err := m.parseColorInput()
+		if err != nil {
+			m.message = fmt.Sprintf("Invalid color: %v", err)
+			return m, nil
+		}
+		name := strings.TrimSpace(m.colorNameBuffer)
+		for i, c := range m.projects[m.selectedProject].Colors {
+			if i != m.editingIndex && name != "" && c.Name == name {
+				m.message = fmt.Sprintf("Another color is already named '%s'", name)
+				return m, nil
+			}
+		}
+		color := Color{Hex: hex, Name: name, Source: source}
+
+		if m.editingIndex < 0 {
+			// Stored hexes may predate normalization, so compare both sides
+			// normalized; #abc and #AABBCC are the same color.
+			for i, c := range m.projects[m.selectedProject].Colors {
+				if existing, ok := normalizeHex(c.Hex); ok && existing == hex {
+					project := &m.projects[m.selectedProject]
+					if pos := slices.Index(project.Collapsed, c.Group); pos >= 0 {
+						project.Collapsed = slices.Delete(project.Collapsed, pos, pos+1)
+						m.saveProjects()
+					}
+					m.currentView = ColorListView
+					m.cursor = m.colorCursorFor(i)
+					m.inputBuffer = ""
+					m.colorNameBuffer = ""
+					m.message = "Color already exists"
+					return m, nil
+				}
+			}
+		}
+
+		index := m.editingIndex
+		if index >= 0 {
+			// Editing keeps the color's role and copy count.
+			existing := &m.projects[m.selectedProject].Colors[index]
+			existing.Hex, existing.Name, existing.Source = color.Hex, color.Name, color.Source
+		} else {
+			m.projects[m.selectedProject].Colors = append(m.projects[m.selectedProject].Colors, color)
+			index = len(m.projects[m.selectedProject].Colors) - 1
 		}
+		m.updateProjectListItems()
+		m.saveProjects()
+		m.currentView = ColorListView
+		m.cursor = m.colorCursorFor(index)
+		m.inputBuffer = ""
+		m.colorNameBuffer = ""
+		m.editingIndex = -1
 	default:
-		if msg.Type == tea.KeyRunes && len(m.inputBuffer) < 7 {
-			m.inputBuffer += string(msg.Runes)
+		if m.focusedField == 1 {
+			m.colorNameBuffer = editInput(m.colorNameBuffer, msg)
+			return m, nil
+		}
+		// Terminals with bracketed paste send the clipboard as one message.
+		if msg.Paste {
+			m.pasteColorInput(string(msg.Runes))
+			return m, nil
 		}
+		// Plain hex codes are capped at "#rrggbbaa"; expressions can be longer.
+		if msg.Type == tea.KeyRunes && strings.HasPrefix(m.inputBuffer, "#") && len(m.inputBuffer) >= 9 {
+			return m, nil
+		}
+		m.inputBuffer = editInput(m.inputBuffer, msg)
 	}
 	return m, nil
 }
 
+// pasteColorInput puts pasted text in the color field if it parses as a
+// color, replacing what was typed. Anything else is reported and the field
+// is left alone. Pasting into the name field just inserts the text.
+func (m *model) pasteColorInput(text string) {
+	text = strings.TrimSpace(text)
+	if m.focusedField == 1 {
+		m.colorNameBuffer += strings.Join(strings.Fields(text), " ")
+		return
+	}
+	previous := m.inputBuffer
+	m.inputBuffer = text
+	if _, _, err := m.parseColorInput(); err != nil {
+		m.inputBuffer = previous
+		m.message = fmt.Sprintf("Can't paste: %v", err)
+	}
+}
+
+// parseColorInput reads the add color form's input the way saving it does:
+// hex codes get a "#" with the autoPrefixHash option, and expressions are
+// returned as the color's source.
+func (m *model) parseColorInput() (hex, source string, err error) {
+	input := strings.TrimSpace(m.inputBuffer)
+	isExpr := strings.Contains(input, "(")
+	if m.config.AutoPrefixHash && !isExpr && !strings.HasPrefix(input, "#") {
+		input = "#" + input
+	}
+	if hex, err = parseColor(input); err != nil {
+		return "", "", err
+	}
+	if isExpr {
+		source = input
+	}
+	return hex, source, nil
+}
+
 func (m *model) updateAddUrl(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "ctrl+c":
-		return m, tea.Quit
+		return m.quit()
 	case "esc":
 		m.currentView = UrlListView
 		m.urlNameBuffer = ""
 		m.inputBuffer = ""
 		m.focusedField = 0
 	case "enter":
-		if m.focusedField == 0 {
-			m.focusedField = 1
+		if m.urlEnterSubmits() {
+			m.submitUrlForm()
 		} else {
-			if m.urlNameBuffer != "" && m.inputBuffer != "" {
-				m.projects[m.selectedProject].Urls = append(m.projects[m.selectedProject].Urls, namedURL{Name: m.urlNameBuffer, URL: m.inputBuffer})
-				m.updateProjectListItems()
-				m.saveProjects()
-				m.currentView = UrlListView
-				m.cursor = len(m.projects[m.selectedProject].Urls) - 1
-				m.urlNameBuffer = ""
-				m.inputBuffer = ""
-				m.focusedField = 0
-			}
+			m.focusedField = 1
 		}
+	case "ctrl+s":
+		m.submitUrlForm()
 	case "backspace":
 		if m.focusedField == 0 {
 			if len(m.urlNameBuffer) > 0 {
@@ -552,6 +2232,9 @@ func (m *model) updateAddUrl(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // --- VIEWS ---
 
 func (m *model) View() string {
+	if m.showHelp {
+		return docStyle.Render(m.viewHelp())
+	}
 	var view string
 	switch m.currentView {
 	case ProjectListView:
@@ -570,6 +2253,40 @@ func (m *model) View() string {
 		view = m.viewAddUrl()
 	case ConfirmDeleteProjectView:
 		view = m.viewConfirmDeleteProject()
+	case ImportView:
+		view = m.viewImport()
+	case RecolorView:
+		view = m.viewRecolor()
+	case PinnedNoteView:
+		view = m.viewPinnedNote()
+	case InfoView:
+		view = m.viewInfo()
+	case ExportMenuView:
+		view = m.viewExportMenu()
+	case SplitSelectView:
+		view = m.viewSplitSelect()
+	case SplitNameView:
+		view = m.viewSplitName()
+	case BulkUrlView:
+		view = m.viewBulkUrl()
+	case ScratchQuitView:
+		view = m.viewScratchQuit()
+	case UnsavedQuitView:
+		view = m.viewUnsavedQuit()
+	case RolePickerView:
+		view = m.viewRolePicker()
+	case SlideshowView:
+		view = m.viewSlideshow()
+	case ColorGroupView:
+		view = m.viewColorGroup()
+	case MoveColorView:
+		view = m.viewMoveColor()
+	case SearchView:
+		view = m.viewSearch()
+	case ContrastView:
+		view = m.viewContrast()
+	case RampView:
+		view = m.viewRamp()
 	}
 	return docStyle.Render(view)
 }
@@ -577,12 +2294,7 @@ func (m *model) View() string {
 func (m *model) viewProjectList() string {
 	var b strings.Builder
 	b.WriteString(m.projectList.View())
-	help := horizontalHelp("↑/↓ navigate", "n new", "d delete", "q quit")
-	b.WriteString("\n" + help)
-
-	if m.message != "" {
-		b.WriteString("\n" + messageStyle.Render(m.message))
-	}
+	b.WriteString(m.footer("↑/↓ navigate", "/ filter", "s search all", "n new", "N scratch", "i import", "y copy name", "Y copy primary color", "d delete", "o sort: "+m.projectSort().String(), "shift+↑/↓ move", "T/B top/bottom", "p slideshow", "c compact", "t descriptions", "I info", "F focus", "? help", m.quitHelp()))
 	return b.String()
 }
 
@@ -592,8 +2304,8 @@ func (m *model) viewConfirmDeleteProject() string {
 		projectName = m.projects[m.selectedProject].Name
 	}
 	var b strings.Builder
-	b.WriteString(headerStyle.Render(fmt.Sprintf("Delete '%s'?", projectName)) + "\n\n")
-	b.WriteString("Are you sure? This action cannot be undone.\n\n")
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Delete '%s'?", m.fitName(projectName, len("Delete ''?")))) + "\n\n")
+	b.WriteString(fmt.Sprintf("Are you sure? You can press z to undo for %d seconds afterwards.\n\n", int(undoWindow.Seconds())))
 	b.WriteString(horizontalHelp("y yes", "n no", "esc cancel"))
 	return b.String()
 }
@@ -601,14 +2313,16 @@ func (m *model) viewConfirmDeleteProject() string {
 func (m *model) updateConfirmDeleteProject(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "y":
+		m.currentView = ProjectListView
 		if m.selectedProject >= 0 && m.selectedProject < len(m.projects) {
+			m.beginUndo()
 			deletedProjectName := m.projects[m.selectedProject].Name
 			m.projects = append(m.projects[:m.selectedProject], m.projects[m.selectedProject+1:]...)
+			m.selectedProject = min(m.selectedProject, max(len(m.projects)-1, 0))
 			m.updateProjectListItems()
 			m.saveProjects()
-			m.message = fmt.Sprintf("Deleted project '%s'", deletedProjectName)
+			return m, m.offerUndo(fmt.Sprintf("Deleted project '%s'", deletedProjectName))
 		}
-		m.currentView = ProjectListView
 	case "n", "esc":
 		m.currentView = ProjectListView
 	}
@@ -620,7 +2334,12 @@ func (m *model) viewProjectMenu() string {
     project := m.projects[m.selectedProject]  
     var b strings.Builder  
   
-    b.WriteString(headerStyle.Render("✨ " + project.Name) + "\n")  
+    b.WriteString(headerStyle.Render("✨ " + m.fitName(project.Name, 3)) + "\n")  
+    if m.fitName(project.Name, 3) != project.Name {
+        // Show the whole name somewhere when the header had to cut it short.
+        b.WriteString(lipgloss.NewStyle().Width(m.contentWidth()).Render(subtleStyle.Render(project.Name)) + "\n\n")
+    }
+    b.WriteString(m.pinnedBanner())
   
     options := []string{"Colors", "URLs"}  
     for i, option := range options {  
@@ -631,8 +2350,8 @@ func (m *model) viewProjectMenu() string {
         }  
     }  
   
-    help := horizontalHelp("↑/↓ navigate", "enter select", "esc back", "q quit")  
-    b.WriteString("\n" + help)  
+    b.WriteString("\n" + subtleStyle.Render("URL copy format: "+m.urlFormatLabel()) + "\n")
+    b.WriteString(m.footer("↑/↓ navigate", "enter select", "y copy name", "p pin note", "u URL format", "r rename", "s split", "c copy project", "b copy bundle", "E edit in $EDITOR", "F focus", "? help", "esc back", m.quitHelp()))
   
     return b.String()  
 }
@@ -641,17 +2360,75 @@ func (m *model) viewColorList() string {
 	project := m.projects[m.selectedProject]
 	var b strings.Builder
 
-	b.WriteString(headerStyle.Render(project.Name) + "\n")
+	b.WriteString(headerStyle.Render(m.fitName(project.Name, 0)) + "\n")
+	b.WriteString(m.pinnedBanner())
+
+	below := m.footer("↑/↓ navigate", "g grid", "enter copy", "alt+enter copy & quit", "f format: "+m.colorFormat.String(), "o sort: "+m.colorSort().String(), "O keep order", "v name/hex first", "space select", "G gradient", "t tints/shades", "i CSS names", "w contrast", "W set background", "c compare two", "R role", "e edit", "r rename", "S section", "m move to project", "tab fold section", "shift+↑/↓ move", "T/B top/bottom", "x export", "n new", "d delete", "H recolor", "F focus", "? help", "esc back", m.quitHelp())
+	if len(project.Colors) > 0 && !hasTrueColor() && !m.focusMode {
+		below = "\n" + subtleStyle.Render("Swatches are approximate: this terminal doesn't support truecolor.") + "\n" + below
+	}
 
 	if len(project.Colors) == 0 {
 		b.WriteString(subtleStyle.Render("No colors yet. Press 'n' to add one.") + "\n")
 	} else {
-		for i, color := range project.Colors {
-			// The unused 'cursor' and 'style' variables have been removed.
-
-			colorBlock := lipgloss.NewStyle().Background(lipgloss.Color(color)).Render("  ")
-			hexCodeStyled := inlineCodeStyle.Render(color)
-			line := fmt.Sprintf("%s %s", colorBlock, hexCodeStyled)
+		rows := m.colorRows()
+		sizes := map[string]int{}
+		for _, c := range project.Colors {
+			sizes[c.Group]++
+		}
+		if m.colorGrid && !m.renaming {
+			m.writeColorGrid(&b, rows, sizes, below)
+			b.WriteString(below)
+			return b.String()
+		}
+		lines := make([]string, len(rows))
+		for i, row := range rows {
+			var item strings.Builder
+			if row.group != "" && (i == 0 || rows[i-1].group != row.group) {
+				header := colorGroupHeader(row, sizes[row.group])
+				if row.index < 0 && m.cursor == i {
+					item.WriteString(selectedItemStyle.Render("> "+header) + "\n")
+				} else {
+					item.WriteString("  " + subtleStyle.Render(header) + "\n")
+				}
+			}
+			if row.index < 0 {
+				lines[i] = item.String()
+				continue
+			}
+			index := row.index
+			color := project.Colors[index]
+
+			colorBlock := swatch(color.Hex)
+			hexCodeStyled := inlineCodeStyle.Render(displayHex(color.Hex, m.config))
+			line := fmt.Sprintf("%s%s %s", m.indexPrefix(i, len(rows)), colorBlock, hexCodeStyled)
+			switch {
+			case m.renaming && m.renameIndex == index:
+				line += " " + m.renameField()
+			case color.Name != "" && m.config.NameFirst:
+				// Lead with the name and keep the hex as a dim aside.
+				line = fmt.Sprintf("%s%s %s %s", m.indexPrefix(i, len(rows)), colorBlock, color.Name, subtleStyle.Render(displayHex(color.Hex, m.config)))
+			case color.Name != "":
+				line += " " + color.Name
+			}
+			if m.showCSSNames {
+				line += " " + cssNameBadge(color.Hex)
+			}
+			if m.config.ShowCopyCounts && color.Copies > 0 {
+				line += " " + subtleStyle.Render(fmt.Sprintf("×%d", color.Copies))
+			}
+			if color.Role != "" {
+				line += " " + inlineCodeStyle.Render(color.Role)
+			}
+			if m.showContrast {
+				line += " " + m.contrastBadge(color.Hex)
+			}
+			if pos := slices.Index(m.colorSelection, index); pos >= 0 {
+				line += " " + messageStyle.Render(fmt.Sprintf("[%d]", pos+1))
+			}
+			if !m.renaming || m.renameIndex != index {
+				line = m.fitName(line, 2)
+			}
 
 			if m.cursor == i {
 				// Style for the cursor: colored but NOT bold
@@ -661,21 +2438,96 @@ func (m *model) viewColorList() string {
 				// Style for the line: uses the existing bold and colored style
 				styledLine := selectedItemStyle.Render(line)
 
-				b.WriteString(styledCursor + styledLine + "\n")
+				item.WriteString(styledCursor + styledLine + "\n")
 			} else {
 				// For unselected lines, just add padding
-				b.WriteString("  " + line + "\n")
+				item.WriteString("  " + line + "\n")
 			}
+			lines[i] = item.String()
 		}
+		m.writeScrolled(&b, lines, m.cursor, below)
 	}
 
-	help := horizontalHelp("↑/↓ navigate", "enter copy", "n new", "d delete", "esc back", "q quit")
-	b.WriteString("\n" + help)
+	b.WriteString(below)
+
+	return b.String()
+}
+
+func (m *model) viewSplitSelect() string {
+	project := m.projects[m.selectedProject]
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Split "+m.fitName(project.Name, len("Split "))) + "\n")
+
+	if len(project.Colors)+len(project.Urls) == 0 {
+		b.WriteString(subtleStyle.Render("This project has nothing to split off.") + "\n")
+	}
+
+	row := func(i int, label string) {
+		box := "[ ]"
+		if m.splitSelected[i] {
+			box = "[x]"
+		}
+		if m.cursor == i {
+			b.WriteString(selectedItemStyle.Render("> "+box+" ") + label + "\n")
+		} else {
+			b.WriteString("  " + box + " " + label + "\n")
+		}
+	}
+	for i, color := range project.Colors {
+		row(i, swatch(color.Hex)+" "+displayHex(color.Hex, m.config))
+	}
+	for i, namedUrl := range project.Urls {
+		row(len(project.Colors)+i, namedUrl.Name)
+	}
 
+	b.WriteString("\n" + horizontalHelp("↑/↓ navigate", "space select", "enter next", "esc cancel"))
 	if m.message != "" {
 		b.WriteString("\n" + messageStyle.Render(m.message))
 	}
+	return b.String()
+}
+
+func (m *model) viewSplitName() string {
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Split Into New Project") + "\n")
+	prompt := fmt.Sprintf("Project name: %s", m.inputBuffer)
+	b.WriteString(inputStyle.Render(prompt) + "\n\n")
+	b.WriteString(horizontalHelp("enter split", "esc back"))
+	return b.String()
+}
+
+func (m *model) viewExportMenu() string {
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Export "+m.fitName(m.projects[m.selectedProject].Name, len("Export "))) + "\n")
+
+	for i, exp := range exporters {
+		if m.exportCursor == i {
+			b.WriteString(selectedItemStyle.Render("> "+exp.name) + "\n")
+		} else {
+			b.WriteString("  " + exp.name + "\n")
+		}
+	}
+
+	b.WriteString("\n" + subtleStyle.Render(fmt.Sprintf("Layout width: %d columns", m.exportWidth())) + "\n")
+	b.WriteString(horizontalHelp("↑/↓ navigate", "enter copy", "esc back"))
+	return b.String()
+}
+
+func (m *model) viewRecolor() string {
+	project := m.projects[m.selectedProject]
+	var b strings.Builder
+
+	b.WriteString(headerStyle.Render("Recolor "+m.fitName(project.Name, len("Recolor "))) + "\n")
+	b.WriteString(fmt.Sprintf("Hue %+.0f° • Lightness %+.0f%%\n\n", m.hueShift, m.lightShift))
+
+	for i, color := range m.shiftedColors() {
+		before := swatch(project.Colors[i].Hex)
+		after := swatch(color.Hex)
+		b.WriteString(fmt.Sprintf("  %s → %s %s\n", before, after, inlineCodeStyle.Render(displayHex(color.Hex, m.config))))
+	}
 
+	help := horizontalHelp("←/→ hue", "↑/↓ lightness", "enter apply", "c apply to copy", "esc cancel")
+	b.WriteString("\n" + help)
 	return b.String()
 }
 
@@ -683,55 +2535,143 @@ func (m *model) viewUrlList() string {
 	project := m.projects[m.selectedProject]
 	var b strings.Builder
 
-	b.WriteString(headerStyle.Render(project.Name) + "\n")
+	b.WriteString(headerStyle.Render(m.fitName(project.Name, 0)) + "\n")
+	b.WriteString(m.pinnedBanner())
+
+	if m.urlFiltering || m.urlFilter != "" {
+		filter := "/" + m.urlFilter
+		if m.urlFiltering {
+			filter += "█"
+		}
+		b.WriteString(selectedItemStyle.Render(filter) + "\n\n")
+	}
+
+	below := m.footer("↑/↓ navigate", "enter copy", "alt+enter copy & quit", "f format: "+m.urlFormatLabel(), "space details", "/ filter", "o sort: "+m.urlSort().String(), "shift+↑/↓ move", "T/B top/bottom", "n new", "P paste many", "e edit", "r rename", "d delete", "F focus", "? help", "esc back", m.quitHelp())
 
+	visible := m.visibleURLs()
 	if len(project.Urls) == 0 {
 		b.WriteString(subtleStyle.Render("No URLs yet. Press 'n' to add one.") + "\n")
+	} else if len(visible) == 0 {
+		b.WriteString(subtleStyle.Render("No URLs match the filter.") + "\n")
 	} else {
-		for i, namedUrl := range project.Urls {
-			if m.cursor == i {
-				b.WriteString(selectedItemStyle.Render("> " + namedUrl.Name) + "\n")
+		lines := make([]string, len(visible))
+		for pos, i := range visible {
+			var item strings.Builder
+			namedUrl := project.Urls[i]
+			index := m.indexPrefix(pos, len(visible))
+			if m.renaming && m.renameIndex == i {
+				item.WriteString(selectedItemStyle.Render("> ") + index + m.renameField() + "\n")
+			} else if m.cursor == pos {
+				item.WriteString(selectedItemStyle.Render("> ") + index + selectedItemStyle.Render(m.fitName(namedUrl.Name, 2+ansi.StringWidth(index))) + "\n")
 			} else {
-				b.WriteString("  " + namedUrl.Name + "\n")
+				item.WriteString("  " + index + m.fitName(namedUrl.Name, 2+ansi.StringWidth(index)) + "\n")
 			}
+			if m.expandedURL == i {
+				item.WriteString(m.viewUrlDetails(namedUrl))
+			}
+			lines[pos] = item.String()
 		}
+		m.writeScrolled(&b, lines, m.cursor, below)
 	}
 
-	help := horizontalHelp("↑/↓ navigate", "enter copy", "n new", "d delete", "esc back", "q quit")
-	b.WriteString("\n" + help)
+	b.WriteString(below)
 
-	if m.message != "" {
-		b.WriteString("\n" + messageStyle.Render(m.message))
-	}
+	return b.String()
+}
 
+// viewUrlDetails renders the expanded details block shown under a URL.
+func (m *model) viewUrlDetails(namedUrl namedURL) string {
+	var b strings.Builder
+	// Wrap long addresses rather than truncating them; this is where the
+	// whole address is shown.
+	address := namedUrl.URL
+	if width := m.contentWidth(); width > 4 {
+		address = ansi.Hardwrap(address, width-4, false)
+	}
+	for _, line := range strings.Split(address, "\n") {
+		b.WriteString("    " + inlineCodeStyle.Render(line) + "\n")
+	}
+	if u, err := url.Parse(namedUrl.URL); err == nil && u.Host != "" {
+		b.WriteString("    " + subtleStyle.Render("host: "+u.Host) + "\n")
+	}
+	b.WriteString("    " + subtleStyle.Render("enter copies this address") + "\n")
 	return b.String()
 }
 
 func (m *model) viewAddProject() string {
 	var b strings.Builder
-	b.WriteString(headerStyle.Render("Add New Project") + "\n")
+	if m.editingIndex >= 0 {
+		b.WriteString(headerStyle.Render("Rename Project") + "\n")
+	} else {
+		b.WriteString(headerStyle.Render("Add New Project") + "\n")
+	}
 	prompt := fmt.Sprintf("Project name: %s", m.inputBuffer)
 	b.WriteString(inputStyle.Render(prompt) + "\n\n")
+	if m.message != "" {
+		b.WriteString(messageStyle.Render(m.message) + "\n\n")
+	}
 	b.WriteString(horizontalHelp("enter save", "esc cancel"))
 	return b.String()
 }
 
 func (m *model) viewAddColor() string {
 	var b strings.Builder
-	b.WriteString(headerStyle.Render("Add New Color") + "\n")
-	prompt := fmt.Sprintf("HEX color: %s", m.inputBuffer)
-	b.WriteString(inputStyle.Render(prompt) + "\n\n")
-	b.WriteString(helpStyle.Render("Enter HEX (e.g., #FF5F87)") + "\n")
-	b.WriteString(horizontalHelp("enter save", "esc cancel"))
+	if m.editingIndex >= 0 {
+		b.WriteString(headerStyle.Render("Edit Color") + "\n")
+	} else {
+		b.WriteString(headerStyle.Render("Add New Color") + "\n")
+	}
+	label := func(name string, field int) string {
+		if m.focusedField == field {
+			return "> " + name + " (editing)"
+		}
+		return "  " + name
+	}
+	prompt := fmt.Sprintf("%s: %s", label("HEX color", 0), m.inputBuffer)
+	// Preview the color as soon as the input parses; swatches drop any alpha.
+	if hex, _, err := m.parseColorInput(); err == nil {
+		prompt += " " + swatch(hex[:7])
+	}
+	namePrompt := fmt.Sprintf("%s: %s", label("Name (optional)", 1), m.colorNameBuffer)
+	if m.focusedField == 0 {
+		b.WriteString(inputStyle.Render(prompt) + "\n")
+		b.WriteString(subtleStyle.Render(namePrompt) + "\n\n")
+	} else {
+		b.WriteString(subtleStyle.Render(prompt) + "\n")
+		b.WriteString(inputStyle.Render(namePrompt) + "\n\n")
+	}
+	if m.config.AutoPrefixHash {
+		b.WriteString(helpStyle.Render("Enter HEX (e.g., #FF5F87, #F58 or just FF5F87; #FF5F87CC adds alpha)") + "\n")
+	} else {
+		b.WriteString(helpStyle.Render("Enter HEX (e.g., #FF5F87 or #F58; #FF5F87CC adds alpha)") + "\n")
+	}
+	b.WriteString(helpStyle.Render("or rgb(255, 95, 135), hsl(340, 100%, 68%)") + "\n")
+	b.WriteString(helpStyle.Render("or an expression: mix(a, b, 50%), lighten/darken/saturate(c, 10%)") + "\n")
+	b.WriteString(horizontalHelp("enter save", "tab switch fields", "ctrl+v paste", "esc cancel"))
+	if m.message != "" {
+		b.WriteString("\n" + messageStyle.Render(m.message))
+	}
 	return b.String()
 }
 
 func (m *model) viewAddUrl() string {
 	var b strings.Builder
-	b.WriteString(headerStyle.Render("Add New URL") + "\n")
+	if m.editingIndex >= 0 {
+		b.WriteString(headerStyle.Render("Edit URL") + "\n")
+	} else {
+		b.WriteString(headerStyle.Render("Add New URL") + "\n")
+	}
 
-	namePrompt := fmt.Sprintf("Name: %s", m.urlNameBuffer)
-	urlPrompt := fmt.Sprintf("URL: %s", m.inputBuffer)
+	// Mark the focused field with text as well as color so it stays obvious on
+	// monochrome terminals.
+	label := func(name string, field int) string {
+		if m.focusedField == field {
+			return "> " + name + " (editing)"
+		}
+		return "  " + name
+	}
+	namePrompt := fmt.Sprintf("%s: %s", label("Name", 0), m.urlNameBuffer)
+	urlPrompt := fmt.Sprintf("%s: %s", label("URL", 1), m.inputBuffer)
 
 	if m.focusedField == 0 {
 		b.WriteString(inputStyle.Render(namePrompt) + "\n")
@@ -740,16 +2680,287 @@ func (m *model) viewAddUrl() string {
 		b.WriteString(subtleStyle.Render(namePrompt) + "\n")
 		b.WriteString(inputStyle.Render(urlPrompt) + "\n\n")
 	}
+	if m.message != "" {
+		b.WriteString(messageStyle.Render(m.message) + "\n\n")
+	}
+
+	enter := "enter next"
+	if m.urlEnterSubmits() {
+		enter = "enter save"
+	}
+	b.WriteString(horizontalHelp(enter, "ctrl+s save", "tab switch fields", "esc cancel"))
+	return b.String()
+}
+
+// urlEnterSubmits reports whether enter saves the URL form from the focused
+// field rather than moving to the URL field. Enter always saves from the last
+// field; with the urlFormEnterSubmits option it also saves from the name
+// field once both fields are filled.
+func (m *model) urlEnterSubmits() bool {
+	if m.focusedField == 1 {
+		return true
+	}
+	return m.config.URLFormEnterSubmits && m.urlNameBuffer != "" && m.inputBuffer != ""
+}
+
+// submitUrlForm adds the URL from the form once both fields are filled, or
+// overwrites the URL being edited.
+func (m *model) submitUrlForm() {
+	if m.urlNameBuffer == "" || m.inputBuffer == "" {
+		m.message = "Fill in both the name and the URL"
+		return
+	}
+	rawURL, err := normalizeURL(m.inputBuffer)
+	if err != nil {
+		m.message = fmt.Sprintf("Invalid URL: %v", err)
+		m.focusedField = 1
+		return
+	}
+	m.inputBuffer = rawURL
+	project := &m.projects[m.selectedProject]
+	name, err := resolveURLName(project.Urls, m.editingIndex, m.urlNameBuffer, m.inputBuffer, m.config.DuplicateURLNames)
+	if err != nil {
+		m.message = fmt.Sprintf("%v; pick another name", err)
+		return
+	}
+	if m.editingIndex >= 0 {
+		project.Urls[m.editingIndex] = namedURL{Name: name, URL: m.inputBuffer}
+		m.saveProjects()
+		m.currentView = UrlListView
+		m.cursor = 0
+		for pos, i := range m.visibleURLs() {
+			if i == m.editingIndex {
+				m.cursor = pos
+			}
+		}
+		m.message = fmt.Sprintf("Updated URL '%s'", name)
+	} else {
+		project.Urls = append(project.Urls, namedURL{Name: name, URL: m.inputBuffer})
+		m.updateProjectListItems()
+		m.saveProjects()
+		m.clearUrlFilter()
+		m.currentView = UrlListView
+		m.cursor = len(project.Urls) - 1
+	}
+	m.editingIndex = -1
+	m.urlNameBuffer = ""
+	m.inputBuffer = ""
+	m.focusedField = 0
+}
+
+func (m *model) viewInfo() string {
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Info") + "\n")
+
+	row := func(label, value string) {
+		b.WriteString(subtleStyle.Render(fmt.Sprintf("%-13s", label)) + value + "\n")
+	}
+
+	if path, err := getDataFilePath(); err == nil {
+		row("Data file", path)
+	}
+	if path, err := getConfigFilePath(); err == nil {
+		row("Config file", path)
+	}
+	row("Projects", fmt.Sprintf("%d", len(m.projects)))
+	if m.manualSave {
+		row("Saving", "manual (ctrl+s), data file is on a removable or network drive")
+	} else {
+		row("Saving", "automatic")
+	}
+
+	switch {
+	case !m.lastBackup.IsZero():
+		row("Last backup", m.lastBackup.Format("2006-01-02 15:04:05"))
+	case m.config.BackupIntervalMinutes > 0 || m.config.BackupEveryChanges > 0:
+		row("Last backup", "none yet this session")
+	default:
+		row("Last backup", "auto-backup disabled")
+	}
+
+	b.WriteString("\n" + horizontalHelp("e export keybindings", "esc back"))
+	if m.message != "" {
+		b.WriteString("\n" + messageStyle.Render(m.message))
+	}
+	return b.String()
+}
+
+// indexPrefix returns the subtle 1-based position shown before list item i of
+// n when the showIndex option is on, padded so the items stay aligned.
+func (m *model) indexPrefix(i, n int) string {
+	if !m.config.ShowIndex {
+		return ""
+	}
+	width := len(fmt.Sprint(n))
+	return subtleStyle.Render(fmt.Sprintf("%*d.", width, i+1)) + " "
+}
+
+// pinnedBanner renders the selected project's pinned note, or nothing if the
+// project has none.
+func (m *model) pinnedBanner() string {
+	note := m.projects[m.selectedProject].Pinned
+	if note == "" {
+		return ""
+	}
+	if width := m.contentWidth(); width > 0 {
+		return lipgloss.NewStyle().Width(width).Render(messageStyle.Render("📌 "+note)) + "\n\n"
+	}
+	return messageStyle.Render("📌 "+note) + "\n\n"
+}
+
+func (m *model) viewPinnedNote() string {
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Pinned Note") + "\n")
+	prompt := fmt.Sprintf("Note: %s", m.inputBuffer)
+	b.WriteString(inputStyle.Render(prompt) + "\n\n")
+	b.WriteString(helpStyle.Render("Leave empty to remove the note") + "\n")
+	b.WriteString(horizontalHelp("enter save", "esc cancel"))
+	return b.String()
+}
+
+func (m *model) viewBulkUrl() string {
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Paste URLs") + "\n")
+	b.WriteString(helpStyle.Render("One per line: a bare URL, or Name<TAB>URL") + "\n\n")
+	b.WriteString(inputStyle.Render(m.inputBuffer+"█") + "\n\n")
+	if m.message != "" {
+		b.WriteString(messageStyle.Render(m.message) + "\n")
+	}
+	b.WriteString(horizontalHelp("enter newline", "tab tab", "ctrl+s add all", "esc cancel"))
+	return b.String()
+}
 
-	b.WriteString(horizontalHelp("enter next/save", "tab switch fields", "esc cancel"))
+func (m *model) viewImport() string {
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Import Project") + "\n")
+	prompt := fmt.Sprintf("File path: %s", m.inputBuffer)
+	b.WriteString(inputStyle.Render(prompt) + "\n\n")
+	b.WriteString(helpStyle.Render("W3C design tokens (.json), terminal themes (.json) or .Xresources") + "\n")
+	b.WriteString(horizontalHelp("enter import", "esc cancel"))
 	return b.String()
 }
 
+// contentWidth is the terminal width available inside docStyle's padding, or
+// zero before the first tea.WindowSizeMsg.
+func (m *model) contentWidth() int {
+	if m.width == 0 {
+		return 0
+	}
+	return max(m.width-docStyle.GetHorizontalFrameSize(), 0)
+}
+
+// fitName truncates a project name, or a rendered list line, with an ellipsis
+// so it fits on one line alongside reserved columns of other text.
+func (m *model) fitName(name string, reserved int) string {
+	if m.width == 0 {
+		return name
+	}
+	return ansi.Truncate(name, max(m.contentWidth()-reserved, 1), "…")
+}
+
+// wrapHelp lays out footer key hints like horizontalHelp, but starts a new
+// line before a hint that would overflow the terminal instead of splitting it.
+func (m *model) wrapHelp(keys ...string) string {
+	width := m.contentWidth()
+	if width == 0 {
+		return horizontalHelp(keys...)
+	}
+	var lines []string
+	line := ""
+	for _, key := range keys {
+		switch {
+		case line == "":
+			line = key
+		case ansi.StringWidth(line+" • "+key) > width:
+			lines = append(lines, horizontalHelp(line))
+			line = key
+		default:
+			line += " • " + key
+		}
+	}
+	return strings.Join(append(lines, horizontalHelp(line)), "\n")
+}
+
+// swatch renders a small block of the given color. On terminals without
+// truecolor support lipgloss maps it to the nearest color the terminal can
+// show; the stored hex is never changed.
+func swatch(hex string) string {
+	return lipgloss.NewStyle().Background(lipgloss.Color(hex)).Render("  ")
+}
+
+func hasTrueColor() bool {
+	return lipgloss.ColorProfile() == termenv.TrueColor
+}
+
+// quitHelp is the footer hint for quitting, which depends on whether q quits.
+func (m *model) quitHelp() string {
+	if m.config.QuitOnQ {
+		return "q quit"
+	}
+	return "ctrl+c quit"
+}
+
+// footer renders the key hints and any status message below a list view. In
+// focus mode both are hidden so only the content shows.
+func (m *model) footer(keys ...string) string {
+	if m.focusMode {
+		return ""
+	}
+	footer := "\n" + m.wrapHelp(keys...)
+	if m.saveError != "" {
+		footer += "\n" + messageStyle.Render("Changes are not saved. "+m.saveError)
+	}
+	if m.manualSave {
+		status := "no unsaved changes"
+		if m.unsaved {
+			status = "unsaved changes, press ctrl+s to save"
+		}
+		footer += "\n" + messageStyle.Render("Manual save: the data file is on a removable or network drive ("+status+")")
+	}
+	if m.dataChanged {
+		footer += "\n" + messageStyle.Render("The data file changed on disk. Press ctrl+r to reload.")
+	}
+	if m.message != "" {
+		footer += "\n" + messageStyle.Render(m.message)
+	}
+	return footer
+}
+
 func horizontalHelp(keys ...string) string {
 	return helpStyle.Render(strings.Join(keys, " • "))
 }
 
 func main() {
+	flag.StringVar(&dataFileOverride, "data-file", "", "use this data file instead of the one in the config dir")
+	listProjects := flag.Bool("list", false, "print each project with its color and URL counts, then exit")
+	listColors := flag.String("list-colors", "", "print the colors of the named project, then exit")
+	export := flag.String("export", "", "print the named project as JSON, then exit")
+	importPath := flag.String("import", "", "add a project from a JSON file (- for stdin), then exit")
+	flag.Parse()
+
+	var err error
+	switch {
+	case flag.Arg(0) == "clean":
+		err = runClean(flag.Args()[1:])
+	case *listProjects:
+		err = runList()
+	case *listColors != "":
+		err = runListColors(*listColors)
+	case *export != "":
+		err = runExport(*export)
+	case *importPath != "":
+		err = runImport(*importPath)
+	default:
+		runTUI()
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runTUI() {
 	m := initialModel()
 	p := tea.NewProgram(&m, tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// messageTimeout is how long a status message stays up without a key press.
+// It outlasts undoWindow so undo toasts expire through expireUndo first.
+const messageTimeout = 4 * time.Second
+
+// clearMessageMsg dismisses the status message with the same id.
+type clearMessageMsg struct{ id int }
+
+// scheduleMessageClear returns the command that dismisses the status message
+// if it changed from previous, so handlers only need to set m.message.
+func (m *model) scheduleMessageClear(previous string) tea.Cmd {
+	if m.message == "" || m.message == previous {
+		return nil
+	}
+	m.messageSeq++
+	id := m.messageSeq
+	return tea.Tick(messageTimeout, func(time.Time) tea.Msg {
+		return clearMessageMsg{id: id}
+	})
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// networkFSTypes are filesystem types treated as network mounts.
+var networkFSTypes = map[string]bool{
+	"nfs": true, "nfs4": true, "cifs": true, "smbfs": true, "smb3": true,
+	"afs": true, "9p": true, "davfs": true, "ncpfs": true, "ceph": true,
+	"glusterfs": true, "sshfs": true, "fuse.sshfs": true, "fuse.rclone": true,
+}
+
+// removableMountPrefixes are where desktops usually mount USB and other
+// removable drives.
+var removableMountPrefixes = []string{"/media/", "/run/media/", "/mnt/", "/Volumes/"}
+
+// onRemovableOrNetworkDrive guesses whether path lives on a removable or
+// network drive, where writes can hang or fail without warning. It goes by
+// the usual removable mount points and, on Linux, the filesystem type in
+// /proc/mounts.
+func onRemovableOrNetworkDrive(path string) bool {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(path)); err == nil {
+		path = filepath.Join(dir, filepath.Base(path))
+	}
+	for _, prefix := range removableMountPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return networkFSTypes[mountFSType(path)]
+}
+
+// mountFSType returns the filesystem type of the mount holding path, or "" if
+// /proc/mounts can't be read.
+func mountFSType(path string) string {
+	data, err := os.ReadFile("/proc/mounts")
+	if err != nil {
+		return ""
+	}
+	// Mount points escape spaces and a few other characters as octal.
+	unescape := strings.NewReplacer(`\040`, " ", `\011`, "\t", `\012`, "\n", `\134`, `\`)
+	best, fsType := "", ""
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		mountPoint := unescape.Replace(fields[1])
+		inside := path == mountPoint || strings.HasPrefix(path, strings.TrimSuffix(mountPoint, "/")+"/")
+		if inside && len(mountPoint) >= len(best) {
+			best, fsType = mountPoint, fields[2]
+		}
+	}
+	return fsType
+}
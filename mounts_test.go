@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestAppConfigDirManualSave stubs dirLookup to check where the data file
+// ends up and whether it is on a drive that turns on manual-save mode.
+func TestAppConfigDirManualSave(t *testing.T) {
+	local := t.TempDir()
+	noDir := func() (string, error) { return "", errors.New("no config dir") }
+
+	tests := []struct {
+		name          string
+		userConfigDir func() (string, error)
+		env           map[string]string
+		failMkdir     string
+		wantDir       string
+		wantWarning   bool
+		wantManual    bool
+	}{
+		{
+			name:          "user config dir on local disk",
+			userConfigDir: func() (string, error) { return local, nil },
+			wantDir:       filepath.Join(local, configDirName),
+		},
+		{
+			name:          "user config dir on removable drive",
+			userConfigDir: func() (string, error) { return "/media/usb/config", nil },
+			wantDir:       "/media/usb/config/" + configDirName,
+			wantManual:    true,
+		},
+		{
+			name:          "XDG_CONFIG_HOME on removable drive",
+			userConfigDir: noDir,
+			env:           map[string]string{"XDG_CONFIG_HOME": "/run/media/me/stick"},
+			wantDir:       "/run/media/me/stick/" + configDirName,
+			wantManual:    true,
+		},
+		{
+			name:          "relative XDG_CONFIG_HOME falls back to HOME",
+			userConfigDir: noDir,
+			env:           map[string]string{"XDG_CONFIG_HOME": "config", "HOME": local},
+			wantDir:       filepath.Join(local, ".config", configDirName),
+		},
+		{
+			name:          "unwritable removable dir falls back to HOME",
+			userConfigDir: func() (string, error) { return "/Volumes/Backup", nil },
+			env:           map[string]string{"HOME": local},
+			failMkdir:     "/Volumes/Backup/" + configDirName,
+			wantDir:       filepath.Join(local, ".config", configDirName),
+		},
+		{
+			name:          "temp dir as a last resort",
+			userConfigDir: noDir,
+			wantDir:       filepath.Join(local, "tmp", configDirName),
+			wantWarning:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookup := dirLookup{
+				userConfigDir: tt.userConfigDir,
+				getenv:        func(key string) string { return tt.env[key] },
+				tempDir:       func() string { return filepath.Join(local, "tmp") },
+				mkdirAll: func(path string, _ os.FileMode) error {
+					if path == tt.failMkdir {
+						return errors.New("read-only")
+					}
+					return nil
+				},
+			}
+			dir, warning, err := lookup.appConfigDir()
+			if err != nil {
+				t.Fatalf("appConfigDir: %v", err)
+			}
+			if dir != tt.wantDir {
+				t.Errorf("dir = %q, want %q", dir, tt.wantDir)
+			}
+			if (warning != "") != tt.wantWarning {
+				t.Errorf("warning = %q, want one: %v", warning, tt.wantWarning)
+			}
+			if got := onRemovableOrNetworkDrive(filepath.Join(dir, dataFileName)); got != tt.wantManual {
+				t.Errorf("manual save = %v, want %v", got, tt.wantManual)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// moveDestinations lists the projects colors can be moved to from the
+// selected project, in project list order.
+func (m *model) moveDestinations() []int {
+	var dests []int
+	for _, i := range projectOrder(m.projects, m.projectSort()) {
+		if i != m.selectedProject {
+			dests = append(dests, i)
+		}
+	}
+	return dests
+}
+
+// moveTargets are the colors MoveColorView moves: the selection, or the
+// color under the cursor.
+func (m *model) moveTargets() []int {
+	if len(m.colorSelection) > 0 {
+		return m.colorSelection
+	}
+	if index := m.selectedColorIndex(); index >= 0 {
+		return []int{index}
+	}
+	return nil
+}
+
+func (m *model) startMoveColor() {
+	if len(m.moveTargets()) == 0 {
+		return
+	}
+	if len(m.projects) < 2 {
+		m.message = "There's no other project to move colors to"
+		return
+	}
+	m.currentView = MoveColorView
+	m.destCursor = 0
+}
+
+// moveColors moves the colors at indexes from the selected project to the
+// project at dest. A moved color drops its role if dest already has a color
+// in that role.
+func (m *model) moveColors(indexes []int, dest int) {
+	source := &m.projects[m.selectedProject]
+	target := &m.projects[dest]
+	for _, i := range indexes {
+		color := source.Colors[i]
+		if roleHolder(target.Colors, color.Role) >= 0 {
+			color.Role = ""
+		}
+		target.Colors = append(target.Colors, color)
+	}
+	sorted := slices.Clone(indexes)
+	slices.Sort(sorted)
+	for _, i := range slices.Backward(sorted) {
+		source.Colors = slices.Delete(source.Colors, i, i+1)
+	}
+}
+
+func (m *model) updateMoveColor(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	dests := m.moveDestinations()
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc":
+		m.currentView = ColorListView
+	case "up", "k":
+		m.destCursor = max(m.destCursor-1, 0)
+	case "down", "j":
+		m.destCursor = min(m.destCursor+1, len(dests)-1)
+	case "enter":
+		dest := dests[m.destCursor]
+		targets := m.moveTargets()
+		m.beginUndo()
+		m.moveColors(targets, dest)
+		m.colorSelection = nil
+		m.updateProjectListItems()
+		m.saveProjects()
+		m.currentView = ColorListView
+		m.cursor = min(m.cursor, max(len(m.colorRows())-1, 0))
+		return m, m.offerUndo(fmt.Sprintf("Moved %d colors to '%s'", len(targets), m.projects[dest].Name))
+	}
+	return m, nil
+}
+
+func (m *model) viewMoveColor() string {
+	project := m.projects[m.selectedProject]
+	var b strings.Builder
+	targets := m.moveTargets()
+	title := fmt.Sprintf("Move %d colors to", len(targets))
+	if len(targets) == 1 {
+		title = "Move " + displayHex(project.Colors[targets[0]].Hex, m.config) + " to"
+	}
+	b.WriteString(headerStyle.Render(title) + "\n")
+
+	for pos, i := range m.moveDestinations() {
+		dest := m.projects[i]
+		label := m.fitName(dest.Name, 2) + subtleStyle.Render(fmt.Sprintf(" (%d colors)", len(dest.Colors)))
+		if m.destCursor == pos {
+			b.WriteString(selectedItemStyle.Render("> ") + label + "\n")
+		} else {
+			b.WriteString("  " + label + "\n")
+		}
+	}
+
+	b.WriteString("\n" + horizontalHelp("↑/↓ navigate", "enter move", "esc back"))
+	return b.String()
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// projectSort selects the order the project list shows projects in. Like
+// colorSort it only affects the display; m.projects keeps the stored order.
+type projectSort int
+
+const (
+	projectSortManual projectSort = iota
+	projectSortName
+	projectSortSize
+	projectSortRecent
+	projectSortCount
+)
+
+// projectSortNames are the names sorts are stored under in the config file.
+var projectSortNames = [projectSortCount]string{
+	projectSortManual: "manual",
+	projectSortName:   "name",
+	projectSortSize:   "size",
+	projectSortRecent: "recent",
+}
+
+func (s projectSort) String() string {
+	switch s {
+	case projectSortName:
+		return "name"
+	case projectSortSize:
+		return "most colors and URLs"
+	case projectSortRecent:
+		return "recently changed"
+	default:
+		return "manual"
+	}
+}
+
+func (s projectSort) next() projectSort {
+	return (s + 1) % projectSortCount
+}
+
+// parseProjectSort looks up a stored sort name. Unknown and empty names mean
+// manual order.
+func parseProjectSort(name string) projectSort {
+	for s, n := range projectSortNames {
+		if n == name {
+			return projectSort(s)
+		}
+	}
+	return projectSortManual
+}
+
+// projectOrder returns indexes into projects in the order s lists them.
+func projectOrder(projects []Project, s projectSort) []int {
+	order := make([]int, len(projects))
+	for i := range order {
+		order[i] = i
+	}
+	switch s {
+	case projectSortName:
+		sort.SliceStable(order, func(a, b int) bool {
+			return strings.ToLower(projects[order[a]].Name) < strings.ToLower(projects[order[b]].Name)
+		})
+	case projectSortSize:
+		size := func(p Project) int { return len(p.Colors) + len(p.Urls) }
+		sort.SliceStable(order, func(a, b int) bool {
+			return size(projects[order[a]]) > size(projects[order[b]])
+		})
+	case projectSortRecent:
+		// Projects never changed since timestamps were added sort last.
+		sort.SliceStable(order, func(a, b int) bool {
+			return projects[order[a]].UpdatedAt.After(projects[order[b]].UpdatedAt)
+		})
+	}
+	return order
+}
+
+// projectSort is the project list's sort.
+func (m *model) projectSort() projectSort {
+	return parseProjectSort(m.config.ProjectSort)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newQuitTestModel(quitOnQ bool) *model {
+	cfg := defaultConfig()
+	cfg.QuitOnQ = quitOnQ
+	l := list.New(nil, list.NewDefaultDelegate(), 0, 0)
+	l.DisableQuitKeybindings()
+	return &model{projectList: l, currentView: ProjectListView, config: cfg}
+}
+
+func quits(cmd tea.Cmd) bool {
+	if cmd == nil {
+		return false
+	}
+	_, ok := cmd().(tea.QuitMsg)
+	return ok
+}
+
+func TestProjectListQuitKeys(t *testing.T) {
+	tests := []struct {
+		key     tea.KeyMsg
+		quitOnQ bool
+		want    bool
+	}{
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}, true, true},
+		{tea.KeyMsg{Type: tea.KeyEsc}, true, true},
+		{tea.KeyMsg{Type: tea.KeyCtrlC}, true, true},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}, false, false},
+		{tea.KeyMsg{Type: tea.KeyEsc}, false, false},
+		{tea.KeyMsg{Type: tea.KeyCtrlC}, false, true},
+	}
+	for _, tt := range tests {
+		m := newQuitTestModel(tt.quitOnQ)
+		_, cmd := m.updateProjectList(tt.key)
+		if got := quits(cmd); got != tt.want {
+			t.Errorf("%s with QuitOnQ=%v: quit = %v, want %v", tt.key, tt.quitOnQ, got, tt.want)
+		}
+	}
+}
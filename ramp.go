@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	defaultRampSteps = 5
+	maxRampSteps     = 9
+)
+
+// generateRamp returns steps tints and steps shades of hex, lightest first,
+// without hex itself. The steps are even in HSL lightness between the base
+// and near-white or near-black, so hue and saturation are kept.
+func generateRamp(hex string, steps int) []string {
+	r, g, b, ok := hexToRGB(hex)
+	if !ok {
+		return nil
+	}
+	h, s, l := rgbToHSL(r, g, b)
+	const lightest, darkest = 0.97, 0.05
+
+	ramp := make([]string, 0, 2*steps)
+	for i := steps; i >= 1; i-- {
+		r, g, b := hslToRGB(h, s, l+(lightest-l)*float64(i)/float64(steps+1))
+		ramp = append(ramp, rgbToHex(r, g, b)+hexAlpha(hex))
+	}
+	for i := 1; i <= steps; i++ {
+		r, g, b := hslToRGB(h, s, l-(l-darkest)*float64(i)/float64(steps+1))
+		ramp = append(ramp, rgbToHex(r, g, b)+hexAlpha(hex))
+	}
+	return ramp
+}
+
+// rampAdditions is the part of the ramp RampView would add: variants the
+// project doesn't already have.
+func (m *model) rampAdditions() []string {
+	project := m.projects[m.selectedProject]
+	base := project.Colors[m.rampIndex].Hex
+	var added []string
+	for _, hex := range generateRamp(base, m.rampSteps) {
+		exists := slices.ContainsFunc(project.Colors, func(c Color) bool {
+			stored, _ := normalizeHex(c.Hex)
+			return stored == hex
+		})
+		if !exists && !slices.Contains(added, hex) {
+			added = append(added, hex)
+		}
+	}
+	return added
+}
+
+func (m *model) startRamp() {
+	index := m.selectedColorIndex()
+	if index < 0 {
+		return
+	}
+	if hex := m.projects[m.selectedProject].Colors[index].Hex; generateRamp(hex, 1) == nil {
+		m.message = fmt.Sprintf("Can't make tints and shades of '%s': it isn't a hex color", hex)
+		return
+	}
+	m.currentView = RampView
+	m.rampIndex = index
+	m.rampSteps = defaultRampSteps
+}
+
+func (m *model) updateRamp(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc", "n":
+		m.currentView = ColorListView
+	case "+", "=", "right", "l":
+		m.rampSteps = min(m.rampSteps+1, maxRampSteps)
+	case "-", "left", "h":
+		m.rampSteps = max(m.rampSteps-1, 1)
+	case "enter", "y":
+		project := &m.projects[m.selectedProject]
+		group := project.Colors[m.rampIndex].Group
+		added := m.rampAdditions()
+		for _, hex := range added {
+			project.Colors = append(project.Colors, Color{Hex: hex, Group: group})
+		}
+		m.updateProjectListItems()
+		m.saveProjects()
+		m.currentView = ColorListView
+		m.message = fmt.Sprintf("Added %d tints and shades", len(added))
+	}
+	return m, nil
+}
+
+func (m *model) viewRamp() string {
+	base := m.projects[m.selectedProject].Colors[m.rampIndex].Hex
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Tints and shades of "+displayHex(base, m.config)) + "\n")
+
+	ramp := generateRamp(base, m.rampSteps)
+	if ramp == nil {
+		// startRamp checks this, but the data file can change underneath.
+		b.WriteString(messageStyle.Render(fmt.Sprintf("'%s' isn't a hex color, so it has no tints or shades.", base)) + "\n")
+		b.WriteString("\n" + horizontalHelp("esc back"))
+		return b.String()
+	}
+
+	added := m.rampAdditions()
+	for i, hex := range ramp {
+		line := swatch(opaqueHex(hex)) + " " + displayHex(hex, m.config)
+		if !slices.Contains(added, hex) {
+			line += subtleStyle.Render(" (already in the project)")
+		}
+		b.WriteString("  " + line + "\n")
+		// The base sits between the tints and the shades.
+		if i == m.rampSteps-1 {
+			b.WriteString(selectedItemStyle.Render("> ") + swatch(opaqueHex(base)) + " " + displayHex(base, m.config) + subtleStyle.Render(" (base)") + "\n")
+		}
+	}
+
+	b.WriteString("\n" + fmt.Sprintf("%d steps each way; adds %d colors", m.rampSteps, len(added)) + "\n")
+	b.WriteString("\n" + horizontalHelp("enter/y add", "+/- steps", "esc cancel"))
+	return b.String()
+}
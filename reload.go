@@ -0,0 +1,161 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadProjects replaces the in-memory projects with the data file's current
+// contents, keeping the selected project open when it still exists. If there
+// are unsaved changes, the first call only warns and a second call reloads.
+func (m *model) reloadProjects() {
+	if m.unsaved && !m.reloadArmed {
+		m.reloadArmed = true
+		if m.manualSave {
+			m.message = "Changes not yet saved with ctrl+s will be lost. Press ctrl+r again to reload."
+		} else {
+			m.message = "The last save failed and unsaved changes will be lost. Press ctrl+r again to reload."
+		}
+		return
+	}
+	m.reloadArmed = false
+
+	projects, err := loadProjects()
+	if err != nil {
+		m.message = fmt.Sprintf("Error reloading: %v", err)
+		return
+	}
+
+	selectedName := ""
+	if m.currentView != ProjectListView && m.selectedProject < len(m.projects) {
+		selectedName = m.projects[m.selectedProject].Name
+	}
+
+	// Scratch projects aren't in the file, so carry them over.
+	for _, p := range m.projects {
+		if p.Scratch {
+			p.Name = uniqueProjectName(projects, p.Name)
+			projects = append(projects, p)
+		}
+	}
+	m.projects = projects
+	m.rememberProjects()
+	m.unsaved = false
+	m.dataChanged = false
+	m.dataHash = dataFileHash()
+	// Undo snapshots and selections refer to the old data.
+	m.undo = nil
+	m.colorSelection = nil
+	m.updateProjectListItems()
+	m.message = fmt.Sprintf("Reloaded %d projects", len(projects))
+
+	if selectedName == "" {
+		return
+	}
+	for i, p := range m.projects {
+		if p.Name == selectedName {
+			m.selectedProject = i
+			m.selectProjectItem(i)
+			switch m.currentView {
+			case ColorListView:
+				m.cursor = min(m.cursor, max(len(p.Colors)-1, 0))
+			case UrlListView:
+				m.cursor = min(m.cursor, max(len(m.visibleURLs())-1, 0))
+				m.expandedURL = -1
+			}
+			return
+		}
+	}
+	m.currentView = ProjectListView
+	m.cursor = 0
+	m.message = fmt.Sprintf("Reloaded %d projects; '%s' no longer exists", len(projects), selectedName)
+}
+
+// dataFileChangedMsg is sent when the watcher sees the data file change on
+// disk in a way the app didn't cause itself.
+type dataFileChangedMsg struct{}
+
+// dataFileHash returns a hash of the data file's current contents, or "" if it
+// can't be read.
+func dataFileHash() string {
+	path, err := getDataFilePath()
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return hashData(data)
+}
+
+func hashData(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// watchDataFile starts watching the data file and returns a channel that
+// receives a value whenever it is written, created or replaced. The directory
+// is watched rather than the file so editors that save by renaming are seen.
+func watchDataFile() (<-chan struct{}, error) {
+	path, err := getDataFilePath()
+	if err != nil {
+		return nil, err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("could not start file watcher: %w", err)
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("could not watch data dir: %w", err)
+	}
+
+	changes := make(chan struct{}, 1)
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != path || !event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+					continue
+				}
+				// Coalesce bursts of events into one pending change.
+				select {
+				case changes <- struct{}{}:
+				default:
+				}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+	return changes, nil
+}
+
+// waitForDataChange blocks until the watcher reports a change.
+func waitForDataChange(changes <-chan struct{}) tea.Cmd {
+	return func() tea.Msg {
+		<-changes
+		return dataFileChangedMsg{}
+	}
+}
+
+// handleDataFileChanged flags an outside change to the data file. Changes
+// whose contents match what the app last wrote or loaded are its own.
+func (m *model) handleDataFileChanged() tea.Cmd {
+	if hash := dataFileHash(); hash != "" && hash != m.dataHash {
+		m.dataChanged = true
+	}
+	return waitForDataChange(m.dataChanges)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startRename begins renaming the color or URL at index in place.
+func (m *model) startRename(index int, name string) {
+	m.renaming = true
+	m.renameIndex = index
+	m.renameBuffer = name
+}
+
+// updateInlineRename handles keys while a color or URL name is being edited
+// in its list.
+func (m *model) updateInlineRename(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc":
+		m.renaming = false
+	case "enter":
+		name := strings.TrimSpace(m.renameBuffer)
+		if err := m.applyRename(name); err != nil {
+			m.message = err.Error()
+			return m, nil
+		}
+		m.renaming = false
+		m.saveProjects()
+	default:
+		m.renameBuffer = editInput(m.renameBuffer, msg)
+	}
+	return m, nil
+}
+
+// applyRename stores name on the color or URL being renamed. Color names must
+// be unique; URL names follow the duplicateURLNames option.
+func (m *model) applyRename(name string) error {
+	project := &m.projects[m.selectedProject]
+	if m.currentView == ColorListView {
+		for i, c := range project.Colors {
+			if i != m.renameIndex && name != "" && c.Name == name {
+				return fmt.Errorf("Another color is already named '%s'", name)
+			}
+		}
+		project.Colors[m.renameIndex].Name = name
+		return nil
+	}
+
+	if name == "" {
+		return fmt.Errorf("URL name can't be empty")
+	}
+	name, err := resolveURLName(project.Urls, m.renameIndex, name, project.Urls[m.renameIndex].URL, m.config.DuplicateURLNames)
+	if err != nil {
+		return err
+	}
+	project.Urls[m.renameIndex].Name = name
+	return nil
+}
+
+// renameField renders the inline rename input.
+func (m *model) renameField() string {
+	return selectedItemStyle.Underline(true).Render(m.renameBuffer) + "█"
+}
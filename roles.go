@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// colorRoles are the semantic roles a color can play in a project. Each role
+// is held by at most one color.
+var colorRoles = []string{"primary", "secondary", "accent", "background", "text"}
+
+// roleOptions are the choices in RolePickerView: no role, then every role.
+func roleOptions() []string {
+	return append([]string{""}, colorRoles...)
+}
+
+// assignRole gives the color at index role, taking it from any other color
+// that had it. It returns the name or hex of that other color, if any.
+func assignRole(colors []Color, index int, role string) (previous string) {
+	if role != "" {
+		for i := range colors {
+			if i != index && colors[i].Role == role {
+				colors[i].Role = ""
+				previous = colors[i].Name
+				if previous == "" {
+					previous = colors[i].Hex
+				}
+			}
+		}
+	}
+	colors[index].Role = role
+	return previous
+}
+
+// roleHolder returns the index of the color with role, or -1.
+func roleHolder(colors []Color, role string) int {
+	if role == "" {
+		return -1
+	}
+	return slices.IndexFunc(colors, func(c Color) bool { return c.Role == role })
+}
+
+func (m *model) updateRolePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	options := roleOptions()
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc":
+		m.currentView = ColorListView
+	case "up", "k":
+		m.roleCursor = max(m.roleCursor-1, 0)
+	case "down", "j":
+		m.roleCursor = min(m.roleCursor+1, len(options)-1)
+	case "enter":
+		role := options[m.roleCursor]
+		colors := m.projects[m.selectedProject].Colors
+		index := m.selectedColorIndex()
+		previous := assignRole(colors, index, role)
+		m.saveProjects()
+		m.currentView = ColorListView
+		switch {
+		case role == "":
+			m.message = "Cleared role"
+		case previous != "":
+			m.message = fmt.Sprintf("Moved %s role from %s", role, previous)
+		default:
+			m.message = fmt.Sprintf("Set role to %s", role)
+		}
+	}
+	return m, nil
+}
+
+func (m *model) viewRolePicker() string {
+	colors := m.projects[m.selectedProject].Colors
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Role for "+displayHex(colors[m.selectedColorIndex()].Hex, m.config)) + "\n")
+
+	for i, role := range roleOptions() {
+		label := role
+		if role == "" {
+			label = "none"
+		}
+		if holder := roleHolder(colors, role); holder >= 0 {
+			label += subtleStyle.Render(" (" + displayHex(colors[holder].Hex, m.config) + ")")
+		}
+		if m.roleCursor == i {
+			b.WriteString(selectedItemStyle.Render("> ") + label + "\n")
+		} else {
+			b.WriteString("  " + label + "\n")
+		}
+	}
+
+	b.WriteString("\n" + horizontalHelp("↑/↓ navigate", "enter assign", "esc back"))
+	return b.String()
+}
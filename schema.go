@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// currentSchemaVersion is the data file format this build writes. Version 0
+// is the original bare array of projects.
+const currentSchemaVersion = 1
+
+// dataFile is the envelope the data file is saved in from version 1 on.
+type dataFile struct {
+	Version  int       `json:"version"`
+	Projects []Project `json:"projects"`
+}
+
+// migrate reads data file contents of any known version and returns the
+// projects in the current shape. Older files are upgraded in memory and
+// rewritten in the current format on the next save.
+func migrate(raw []byte) ([]Project, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && raw[0] == '[' {
+		// Version 0. Colors saved as bare hex strings are read by
+		// Color.UnmarshalJSON.
+		var projects []Project
+		if err := json.Unmarshal(raw, &projects); err != nil {
+			return nil, err
+		}
+		return projects, nil
+	}
+
+	var file dataFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, err
+	}
+	if file.Version > currentSchemaVersion {
+		return nil, fmt.Errorf("data file is version %d but this version of diamonds only reads up to %d; please upgrade", file.Version, currentSchemaVersion)
+	}
+	return file.Projects, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMigrateLegacyArray(t *testing.T) {
+	raw := `[{"name": "Brand", "colors": ["#ff0000", {"hex": "#00ff00", "name": "Green"}], "urls": []}]`
+	projects, err := migrate([]byte(raw))
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	want := []Color{{Hex: "#ff0000"}, {Hex: "#00ff00", Name: "Green"}}
+	if len(projects) != 1 || projects[0].Name != "Brand" || !reflect.DeepEqual(projects[0].Colors, want) {
+		t.Errorf("migrate = %+v, want one project 'Brand' with colors %+v", projects, want)
+	}
+}
+
+func TestMigrateEnvelope(t *testing.T) {
+	raw := `{"version": 1, "projects": [{"name": "Brand", "colors": [{"hex": "#ff0000", "role": "primary"}], "urls": []}]}`
+	projects, err := migrate([]byte(raw))
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	want := []Color{{Hex: "#ff0000", Role: "primary"}}
+	if len(projects) != 1 || !reflect.DeepEqual(projects[0].Colors, want) {
+		t.Errorf("migrate = %+v, want colors %+v", projects, want)
+	}
+
+	if _, err := migrate([]byte(`{"version": 99, "projects": []}`)); err == nil {
+		t.Error("migrate accepted a newer version")
+	}
+}
+
+// TestSaveRoundTrip writes projects in both file shapes and reads them back.
+func TestSaveRoundTrip(t *testing.T) {
+	projects := []Project{{
+		Name:   "Brand",
+		Colors: []Color{{Hex: "#ff0000", Name: "Red", Role: "primary"}, {Hex: "#00ff00"}},
+		Urls:   []namedURL{{Name: "Site", URL: "https://example.com"}},
+	}}
+
+	tests := []struct {
+		name       string
+		legacy     bool
+		wantPrefix string
+		wantColors []Color
+	}{
+		{"envelope", false, "{", projects[0].Colors},
+		// The legacy shape only keeps hexes.
+		{"legacy", true, "[", []Color{{Hex: "#ff0000"}, {Hex: "#00ff00"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataFileOverride = filepath.Join(t.TempDir(), dataFileName)
+			t.Cleanup(func() { dataFileOverride = "" })
+
+			m := model{config: Config{LegacyColors: tt.legacy}, projects: projects}
+			if m.writeProjects(); m.unsaved {
+				t.Fatalf("write failed: %s", m.saveError)
+			}
+			data, err := os.ReadFile(dataFileOverride)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(strings.TrimSpace(string(data)), tt.wantPrefix) {
+				t.Errorf("file starts %.20q, want %q", data, tt.wantPrefix)
+			}
+
+			loaded, err := loadProjects()
+			if err != nil {
+				t.Fatalf("loadProjects: %v", err)
+			}
+			if len(loaded) != 1 || !reflect.DeepEqual(loaded[0].Colors, tt.wantColors) || !reflect.DeepEqual(loaded[0].Urls, projects[0].Urls) {
+				t.Errorf("loaded %+v, want colors %+v and urls %+v", loaded, tt.wantColors, projects[0].Urls)
+			}
+		})
+	}
+}
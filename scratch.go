@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const scratchProjectName = "Scratch"
+
+// newScratchProject adds an empty scratch project and returns its index.
+func (m *model) newScratchProject() int {
+	m.projects = append(m.projects, Project{
+		Name:    uniqueProjectName(m.projects, scratchProjectName),
+		Colors:  []Color{},
+		Urls:    []namedURL{},
+		Scratch: true,
+	})
+	m.updateProjectListItems()
+	return len(m.projects) - 1
+}
+
+// savedProjects returns the projects that belong in the data file, leaving out
+// scratch projects.
+func savedProjects(projects []Project) []Project {
+	saved := make([]Project, 0, len(projects))
+	for _, p := range projects {
+		if !p.Scratch {
+			saved = append(saved, p)
+		}
+	}
+	return saved
+}
+
+// scratchProjects returns the names of the session's scratch projects.
+func (m *model) scratchProjects() []string {
+	var names []string
+	for _, p := range m.projects {
+		if p.Scratch {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+// quit exits the app. Changes that aren't on disk are confirmed first, then
+// it asks whether to keep any scratch projects when the promptScratchOnQuit
+// option is on. Every quit key goes through here.
+func (m *model) quit() (tea.Model, tea.Cmd) {
+	if m.manualSave && m.unsaved {
+		// Write pending changes on the way out.
+		m.writeProjects()
+	}
+	if m.unsaved && m.currentView != UnsavedQuitView && m.currentView != ScratchQuitView {
+		m.quitFrom = m.currentView
+		m.currentView = UnsavedQuitView
+		return m, nil
+	}
+	if m.config.PromptScratchOnQuit && len(m.scratchProjects()) > 0 && m.currentView != ScratchQuitView {
+		m.currentView = ScratchQuitView
+		return m, nil
+	}
+	return m, tea.Quit
+}
+
+func (m *model) updateScratchQuit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "n":
+		return m, tea.Quit
+	case "y":
+		for i := range m.projects {
+			m.projects[i].Scratch = false
+		}
+		m.writeProjects()
+		if m.unsaved {
+			// Stay open so the save error is visible and nothing is lost.
+			m.currentView = ProjectListView
+			return m, nil
+		}
+		return m, tea.Quit
+	case "esc":
+		m.currentView = ProjectListView
+	}
+	return m, nil
+}
+
+func (m *model) viewScratchQuit() string {
+	names := m.scratchProjects()
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Keep scratch projects?") + "\n\n")
+	b.WriteString(fmt.Sprintf("Scratch projects are discarded on quit: %s\n\n", strings.Join(names, ", ")))
+	b.WriteString(horizontalHelp("y keep and quit", "n discard and quit", "esc cancel"))
+	return b.String()
+}
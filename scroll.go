@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// listBudget is how many lines a scrolled list may take once the text above
+// and below it is drawn, or zero before the first tea.WindowSizeMsg. One line
+// is kept for the position indicator.
+func (m *model) listBudget(above, below string) int {
+	if m.height == 0 {
+		return 0
+	}
+	used := docStyle.GetVerticalFrameSize() + strings.Count(above, "\n") + strings.Count(below, "\n") + 2
+	return max(m.height-used, 1)
+}
+
+// scrollWindow picks the rows of a list to draw, given how many lines each
+// row takes and the row the cursor is on. The window only moves when the
+// cursor reaches one of its edges, so paging through a long list doesn't jump
+// around. A budget of zero shows every row.
+func (m *model) scrollWindow(heights []int, budget, cursor int) (start, end int) {
+	if budget <= 0 {
+		return 0, len(heights)
+	}
+	cursor = min(max(cursor, 0), max(len(heights)-1, 0))
+	m.listOffset = min(max(m.listOffset, 0), cursor)
+
+	fits := func(start, end int) bool {
+		lines := 0
+		for _, h := range heights[start:end] {
+			lines += h
+		}
+		return lines <= budget
+	}
+	// Scroll down until the cursor row fits, keeping at least that row.
+	for m.listOffset < cursor && !fits(m.listOffset, cursor+1) {
+		m.listOffset++
+	}
+	end = m.listOffset + 1
+	for end < len(heights) && fits(m.listOffset, end+1) {
+		end++
+	}
+	// Near the bottom, pull earlier rows in rather than leave a gap.
+	for m.listOffset > 0 && fits(m.listOffset-1, end) {
+		m.listOffset--
+	}
+	return m.listOffset, min(end, len(heights))
+}
+
+// scrollIndicator reports which rows of a scrolled list are shown, or nothing
+// when they all fit.
+func scrollIndicator(start, end, total int) string {
+	if start == 0 && end == total {
+		return ""
+	}
+	return subtleStyle.Render(fmt.Sprintf("%d-%d of %d", start+1, end, total)) + "\n"
+}
+
+// writeScrolled appends the rows of a list that fit between what b already
+// holds and the text that will follow it, plus the position indicator. cursor
+// is the row that must stay visible.
+func (m *model) writeScrolled(b *strings.Builder, rows []string, cursor int, below string) {
+	heights := make([]int, len(rows))
+	for i, row := range rows {
+		heights[i] = strings.Count(row, "\n")
+	}
+	start, end := m.scrollWindow(heights, m.listBudget(b.String(), below), cursor)
+	for _, row := range rows[start:end] {
+		b.WriteString(row)
+	}
+	b.WriteString(scrollIndicator(start, end, len(rows)))
+}
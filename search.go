@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// searchResult is one match in SearchView: a project, or a color or URL
+// within it when index is set.
+type searchResult struct {
+	project int
+	view    ViewState // ProjectMenuView, ColorListView or UrlListView
+	index   int       // Color or URL index, or -1 for the project itself
+}
+
+// searchProjects matches query against project names, color hex codes and
+// names, and URL names and addresses, ignoring case. Hex codes also match in
+// shorthand, so "#abc" finds "#aabbcc".
+func searchProjects(projects []Project, query string) []searchResult {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+	hex, isHex := normalizeHex(query)
+	if !isHex {
+		hex, isHex = normalizeHex("#" + query)
+	}
+	matches := func(s string) bool { return strings.Contains(strings.ToLower(s), query) }
+
+	var results []searchResult
+	for p, project := range projects {
+		if matches(project.Name) {
+			results = append(results, searchResult{project: p, view: ProjectMenuView, index: -1})
+		}
+		for i, c := range project.Colors {
+			stored, _ := normalizeHex(c.Hex)
+			if matches(c.Hex) || matches(c.Name) || isHex && stored == hex {
+				results = append(results, searchResult{project: p, view: ColorListView, index: i})
+			}
+		}
+		for i, u := range project.Urls {
+			if matches(u.Name) || matches(u.URL) {
+				results = append(results, searchResult{project: p, view: UrlListView, index: i})
+			}
+		}
+	}
+	return results
+}
+
+func (m *model) startSearch() {
+	m.currentView = SearchView
+	m.inputBuffer = ""
+	m.cursor = 0
+}
+
+func (m *model) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	results := searchProjects(m.projects, m.inputBuffer)
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc":
+		m.currentView = ProjectListView
+		m.inputBuffer = ""
+	case "up", "ctrl+k":
+		m.cursor = max(m.cursor-1, 0)
+	case "down", "ctrl+j":
+		m.cursor = min(m.cursor+1, max(len(results)-1, 0))
+	case "enter":
+		if m.cursor < len(results) {
+			m.openSearchResult(results[m.cursor])
+		}
+	default:
+		m.inputBuffer = editInput(m.inputBuffer, msg)
+		m.cursor = 0
+	}
+	return m, nil
+}
+
+// openSearchResult jumps to the view holding r with the cursor on it.
+func (m *model) openSearchResult(r searchResult) {
+	m.selectedProject = r.project
+	m.selectProjectItem(r.project)
+	m.colorSelection = nil
+	m.inputBuffer = ""
+	m.currentView = r.view
+	m.cursor = 0
+
+	project := &m.projects[r.project]
+	switch r.view {
+	case ColorListView:
+		// A match inside a folded section should be visible.
+		group := project.Colors[r.index].Group
+		if pos := slices.Index(project.Collapsed, group); pos >= 0 {
+			project.Collapsed = slices.Delete(project.Collapsed, pos, pos+1)
+			m.saveProjects()
+		}
+		m.cursor = m.colorCursorFor(r.index)
+	case UrlListView:
+		m.clearUrlFilter()
+		m.cursor = slices.Index(m.visibleURLs(), r.index)
+	}
+}
+
+func (m *model) viewSearch() string {
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Search all projects") + "\n")
+	b.WriteString(inputStyle.Render("Find: "+m.inputBuffer) + "\n\n")
+
+	below := "\n" + horizontalHelp("type to search", "↑/↓ navigate", "enter open", "esc back")
+	results := searchProjects(m.projects, m.inputBuffer)
+	switch {
+	case strings.TrimSpace(m.inputBuffer) == "":
+		b.WriteString(subtleStyle.Render("Matches project names, colors (hex or name) and URLs (name or address).") + "\n")
+	case len(results) == 0:
+		b.WriteString(subtleStyle.Render("No matches.") + "\n")
+	default:
+		lines := make([]string, len(results))
+		for pos, r := range results {
+			project := m.projects[r.project]
+			var label string
+			switch r.view {
+			case ColorListView:
+				c := project.Colors[r.index]
+				label = swatch(c.Hex) + " " + displayHex(c.Hex, m.config)
+				if c.Name != "" {
+					label += " " + c.Name
+				}
+				label += subtleStyle.Render(" in " + project.Name)
+			case UrlListView:
+				u := project.Urls[r.index]
+				label = u.Name + subtleStyle.Render(fmt.Sprintf(" %s in %s", u.URL, project.Name))
+			default:
+				label = project.Name + subtleStyle.Render(" (project)")
+			}
+			label = m.fitName(label, 2)
+			if m.cursor == pos {
+				lines[pos] = selectedItemStyle.Render("> ") + label + "\n"
+			} else {
+				lines[pos] = "  " + label + "\n"
+			}
+		}
+		m.writeScrolled(&b, lines, m.cursor, below)
+	}
+
+	b.WriteString(below)
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const slideSwatchHeight = 4
+
+// slideTickMsg advances the slideshow when auto-play is on. seq ties it to the
+// auto-play run that scheduled it, so stale ticks are ignored.
+type slideTickMsg struct {
+	seq int
+}
+
+func (m *model) startSlideshow() {
+	m.currentView = SlideshowView
+	m.slide = max(m.selectedProjectIndex(), 0)
+	m.slideAuto = false
+	m.slideSeq++
+}
+
+// scheduleSlide returns a tick for the next auto-play step.
+func (m *model) scheduleSlide() tea.Cmd {
+	seq := m.slideSeq
+	interval := time.Duration(max(m.config.SlideshowSeconds, 1)) * time.Second
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return slideTickMsg{seq: seq}
+	})
+}
+
+func (m *model) handleSlideTick(msg slideTickMsg) tea.Cmd {
+	if m.currentView != SlideshowView || !m.slideAuto || msg.seq != m.slideSeq {
+		return nil
+	}
+	m.slide = (m.slide + 1) % len(m.projects)
+	return m.scheduleSlide()
+}
+
+func (m *model) updateSlideshow(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	n := len(m.projects)
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc":
+		m.currentView = ProjectListView
+		m.selectProjectItem(m.slide)
+		m.slideAuto = false
+	case "right", "l", " ":
+		m.slide = (m.slide + 1) % n
+	case "left", "h":
+		m.slide = (m.slide - 1 + n) % n
+	case "a":
+		m.slideAuto = !m.slideAuto
+		m.slideSeq++
+		if m.slideAuto {
+			return m, m.scheduleSlide()
+		}
+	}
+	return m, nil
+}
+
+func (m *model) viewSlideshow() string {
+	project := m.projects[m.slide]
+	var b strings.Builder
+	b.WriteString(headerStyle.Render(m.fitName(project.Name, 0)) + "\n")
+	b.WriteString(subtleStyle.Render(fmt.Sprintf("%d / %d", m.slide+1, len(m.projects))) + "\n\n")
+
+	if len(project.Colors) == 0 {
+		b.WriteString(subtleStyle.Render("This project has no colors.") + "\n")
+	} else {
+		b.WriteString(m.slideSwatches(project.Colors) + "\n")
+	}
+
+	auto := "a auto-play"
+	if m.slideAuto {
+		auto = "a stop auto-play"
+	}
+	b.WriteString("\n" + horizontalHelp("←/→ previous/next", auto, "esc back"))
+	return b.String()
+}
+
+// slideSwatches renders colors as large labelled blocks, as many per row as
+// fit the terminal.
+func (m *model) slideSwatches(colors []Color) string {
+	width := m.contentWidth()
+	if width == 0 {
+		width = 80
+	}
+	blockWidth := min(max(width/len(colors), 10), 20)
+	perRow := max(width/blockWidth, 1)
+
+	var rows []string
+	for start := 0; start < len(colors); start += perRow {
+		var blocks []string
+		for _, c := range colors[start:min(start+perRow, len(colors))] {
+			block := lipgloss.NewStyle().Background(lipgloss.Color(c.Hex)).Width(blockWidth - 1).Height(slideSwatchHeight).Render("")
+			label := lipgloss.NewStyle().Width(blockWidth).Render(ansi.Truncate(displayHex(c.Hex, m.config), blockWidth-1, "…"))
+			name := lipgloss.NewStyle().Width(blockWidth).Render(subtleStyle.Render(ansi.Truncate(c.Name, blockWidth-1, "…")))
+			blocks = append(blocks, lipgloss.JoinVertical(lipgloss.Left, block+" ", label, name))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, blocks...))
+	}
+	return strings.Join(rows, "\n")
+}
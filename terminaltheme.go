@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ansiColorNames names the 16 ANSI colors in color0..color15 order.
+var ansiColorNames = [16]string{
+	"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white",
+	"bright black", "bright red", "bright green", "bright yellow",
+	"bright blue", "bright magenta", "bright cyan", "bright white",
+}
+
+// windowsTerminalKeys are the ANSI color keys of a Windows Terminal scheme, in
+// color0..color15 order.
+var windowsTerminalKeys = [16]string{
+	"black", "red", "green", "yellow", "blue", "purple", "cyan", "white",
+	"brightBlack", "brightRed", "brightGreen", "brightYellow",
+	"brightBlue", "brightPurple", "brightCyan", "brightWhite",
+}
+
+// themeExtraKeys are the non-ANSI colors a terminal theme may also define.
+var themeExtraKeys = []string{"background", "foreground", "cursor", "cursorColor", "selectionBackground"}
+
+// xresourcesColorLine matches "*.color4: #hex", "URxvt*color4: #hex" and
+// "*background: #hex".
+var xresourcesColorLine = regexp.MustCompile(`^[\w.*-]*[.*](color\d+|background|foreground|cursorColor)\s*:\s*(\S+)`)
+
+// themeCollector gathers terminal theme colors into the 16 ANSI slots plus
+// extras, so every theme format produces projects in the same order.
+type themeCollector struct {
+	ansi    [16]string
+	extras  map[string]string
+	skipped int
+}
+
+func (c *themeCollector) set(key, value string) {
+	value, ok := normalizeHex(value)
+	if !ok {
+		c.skipped++
+		return
+	}
+	if n, ok := strings.CutPrefix(key, "color"); ok {
+		if i, err := strconv.Atoi(n); err == nil && i >= 0 && i < 16 {
+			c.ansi[i] = value
+			return
+		}
+		c.skipped++
+		return
+	}
+	if c.extras == nil {
+		c.extras = map[string]string{}
+	}
+	c.extras[key] = value
+}
+
+func (c *themeCollector) project(name string) (Project, int, error) {
+	project := Project{Name: name, Colors: []Color{}, Urls: []namedURL{}}
+	for i, hex := range c.ansi {
+		if hex != "" {
+			project.Colors = append(project.Colors, Color{Hex: hex, Name: ansiColorNames[i]})
+		}
+	}
+	for _, key := range themeExtraKeys {
+		if hex, ok := c.extras[key]; ok {
+			project.Colors = append(project.Colors, Color{Hex: hex, Name: key})
+		}
+	}
+	if len(project.Colors) == 0 {
+		return Project{}, 0, fmt.Errorf("no terminal colors found")
+	}
+	return project, c.skipped, nil
+}
+
+// importXresources reads color0..color15, background, foreground and
+// cursorColor from an .Xresources file, resolving values set with #define.
+// Comments and other resources are ignored.
+func importXresources(data []byte, name string) (Project, int, error) {
+	var c themeCollector
+	defines := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "!") {
+			continue
+		}
+		if fields := strings.Fields(line); len(fields) == 3 && fields[0] == "#define" {
+			defines[fields[1]] = fields[2]
+			continue
+		}
+		if match := xresourcesColorLine.FindStringSubmatch(line); match != nil {
+			value := match[2]
+			if defined, ok := defines[value]; ok {
+				value = defined
+			}
+			c.set(match[1], value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Project{}, 0, fmt.Errorf("could not read Xresources: %w", err)
+	}
+	return c.project(name)
+}
+
+// isTerminalThemeJSON reports whether a JSON document looks like a terminal
+// theme rather than design tokens.
+func isTerminalThemeJSON(data []byte) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return false
+	}
+	if _, ok := fields["color0"]; ok {
+		return true
+	}
+	// Design tokens often have a "colors" group too, so only the theme
+	// shapes of it count.
+	for _, key := range []string{"colors", "palette"} {
+		if raw, ok := fields[key]; ok && isThemePalette(raw) {
+			return true
+		}
+	}
+	// Design tokens may have a "red" group, but never a plain string there.
+	for _, key := range windowsTerminalKeys {
+		var value string
+		if raw, ok := fields[key]; ok && json.Unmarshal(raw, &value) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// isThemePalette reports whether raw is a terminal theme's "colors" or
+// "palette": an array of hex strings, or an object mapping colorN, ANSI color
+// names and theme extras to strings.
+func isThemePalette(raw json.RawMessage) bool {
+	var list []string
+	if json.Unmarshal(raw, &list) == nil {
+		for _, value := range list {
+			if !isHexColor(strings.TrimSpace(value)) {
+				return false
+			}
+		}
+		return len(list) > 0
+	}
+
+	var nested map[string]string
+	if json.Unmarshal(raw, &nested) != nil || len(nested) == 0 {
+		return false
+	}
+	for key := range nested {
+		if !isThemeColorKey(key) {
+			return false
+		}
+	}
+	return true
+}
+
+// isThemeColorKey reports whether key names a terminal theme color.
+func isThemeColorKey(key string) bool {
+	if n, ok := strings.CutPrefix(key, "color"); ok {
+		if _, err := strconv.Atoi(n); err == nil {
+			return true
+		}
+	}
+	return ansiColorIndex(key) >= 0 || slices.Contains(themeExtraKeys, key)
+}
+
+// ansiColorIndex returns the ANSI slot for a color name such as "red",
+// "bright red" or "brightRed", or -1.
+func ansiColorIndex(key string) int {
+	if i := slices.Index(ansiColorNames[:], key); i >= 0 {
+		return i
+	}
+	return slices.Index(windowsTerminalKeys[:], key)
+}
+
+// importTerminalThemeJSON reads the common JSON terminal theme layouts:
+// Windows Terminal schemes (black, red, ..., brightWhite), color0..color15
+// keys at the top level or under "colors", and a 16-entry "colors" or
+// "palette" array.
+func importTerminalThemeJSON(data []byte, name string) (Project, int, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return Project{}, 0, fmt.Errorf("could not parse theme: %w", err)
+	}
+	if n, ok := fields["name"]; ok {
+		var themeName string
+		if json.Unmarshal(n, &themeName) == nil && themeName != "" {
+			name = themeName
+		}
+	}
+
+	var c themeCollector
+	setString := func(key string, raw json.RawMessage) {
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			c.skipped++
+			return
+		}
+		c.set(key, value)
+	}
+
+	for i, key := range windowsTerminalKeys {
+		if raw, ok := fields[key]; ok {
+			setString(fmt.Sprintf("color%d", i), raw)
+		}
+	}
+	for _, key := range []string{"colors", "palette"} {
+		raw, ok := fields[key]
+		if !ok {
+			continue
+		}
+		var list []string
+		var nested map[string]json.RawMessage
+		switch {
+		case json.Unmarshal(raw, &list) == nil:
+			for i, value := range list {
+				c.set(fmt.Sprintf("color%d", i), value)
+			}
+		case json.Unmarshal(raw, &nested) == nil:
+			for key, value := range nested {
+				if i := ansiColorIndex(key); i >= 0 {
+					key = fmt.Sprintf("color%d", i)
+				}
+				setString(key, value)
+			}
+		}
+	}
+	for key, raw := range fields {
+		if strings.HasPrefix(key, "color") && key != "colors" {
+			setString(key, raw)
+		}
+	}
+	for _, key := range themeExtraKeys {
+		if raw, ok := fields[key]; ok {
+			setString(key, raw)
+		}
+	}
+	return c.project(name)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// projectPrint is a project's content without its UpdatedAt, used to tell
+// whether a save changed it.
+func projectPrint(p Project) string {
+	p.UpdatedAt = time.Time{}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// rememberProjects records every project's current content, so the next
+// stampProjects only touches projects changed after this point.
+func (m *model) rememberProjects() {
+	m.projectPrints = make(map[string]string, len(m.projects))
+	for _, p := range m.projects {
+		m.projectPrints[p.Name] = projectPrint(p)
+	}
+}
+
+// stampProjects sets UpdatedAt on the projects that changed since they were
+// last remembered, and CreatedAt on the ones that are new. Saving calls it,
+// so handlers don't have to track which projects they touched. Projects from
+// files that predate timestamps keep a zero CreatedAt rather than a made-up
+// one.
+func (m *model) stampProjects() {
+	now := time.Now().Truncate(time.Second)
+	for i := range m.projects {
+		p := &m.projects[i]
+		saved, known := m.projectPrints[p.Name]
+		if !known && p.CreatedAt.IsZero() {
+			p.CreatedAt = now
+		}
+		if projectPrint(*p) != saved {
+			p.UpdatedAt = now
+		}
+	}
+	m.rememberProjects()
+}
+
+// renameProjectPrint carries a project's remembered content over to its new
+// name, so a renamed project counts as changed rather than new.
+func (m *model) renameProjectPrint(oldName, newName string) {
+	if saved, ok := m.projectPrints[oldName]; ok && oldName != newName {
+		delete(m.projectPrints, oldName)
+		m.projectPrints[newName] = saved
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// undoWindow is how long a delete can be undone after its toast appears.
+const undoWindow = 3 * time.Second
+
+// pendingUndo is the state to restore if the last delete is undone before
+// its toast expires.
+type pendingUndo struct {
+	id              int
+	projects        []Project
+	selectedProject int
+	cursor          int
+	toast           string
+}
+
+// undoExpiredMsg closes the undo window for the pending undo with the same id.
+type undoExpiredMsg struct{ id int }
+
+func cloneProjects(projects []Project) []Project {
+	clones := make([]Project, len(projects))
+	for i, p := range projects {
+		clones[i] = cloneProject(p)
+	}
+	return clones
+}
+
+// beginUndo snapshots the current state. Call it before a delete, then call
+// offerUndo once the delete is done.
+func (m *model) beginUndo() {
+	m.undoSeq++
+	m.undo = &pendingUndo{
+		id:              m.undoSeq,
+		projects:        cloneProjects(m.projects),
+		selectedProject: m.selectedProject,
+		cursor:          m.cursor,
+	}
+}
+
+// offerUndo shows a toast for the delete described by action and returns the
+// command that expires it.
+func (m *model) offerUndo(action string) tea.Cmd {
+	if m.undo == nil {
+		return nil
+	}
+	m.undo.toast = fmt.Sprintf("%s — press z to undo (%ds)", action, int(undoWindow.Seconds()))
+	m.message = m.undo.toast
+
+	id := m.undo.id
+	return tea.Tick(undoWindow, func(time.Time) tea.Msg {
+		return undoExpiredMsg{id: id}
+	})
+}
+
+// expireUndo drops the pending undo if it is the one that timed out.
+func (m *model) expireUndo(id int) {
+	if m.undo == nil || m.undo.id != id {
+		return
+	}
+	if m.message == m.undo.toast {
+		m.message = ""
+	}
+	m.undo = nil
+}
+
+// applyUndo restores the state captured by the pending undo, if any.
+func (m *model) applyUndo() {
+	if m.undo == nil {
+		return
+	}
+	m.projects = m.undo.projects
+	m.selectedProject = m.undo.selectedProject
+	m.cursor = m.undo.cursor
+	m.undo = nil
+	m.colorSelection = nil
+	m.updateProjectListItems()
+	m.saveProjects()
+	m.message = "Undid delete"
+}
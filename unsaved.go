@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// updateUnsavedQuit handles the prompt quit shows when changes aren't on disk
+// yet, because the last save failed or manual save mode is waiting.
+func (m *model) updateUnsavedQuit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "y":
+		return m.quit()
+	case "s":
+		m.writeProjects()
+		if m.unsaved {
+			return m, nil
+		}
+		return m.quit()
+	case "n", "esc":
+		m.currentView = m.quitFrom
+	}
+	return m, nil
+}
+
+func (m *model) viewUnsavedQuit() string {
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Quit? Unsaved changes") + "\n\n")
+	if m.saveError != "" {
+		b.WriteString(messageStyle.Render(m.saveError) + "\n\n")
+	} else {
+		b.WriteString("Some changes haven't been written to the data file yet.\n\n")
+	}
+	b.WriteString(horizontalHelp("y quit without saving", "s save and quit", "n cancel"))
+	return b.String()
+}
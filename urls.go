@@ -0,0 +1,229 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// urlFormat selects how a URL is written to the clipboard.
+type urlFormat int
+
+const (
+	urlFormatRaw urlFormat = iota
+	urlFormatMarkdown
+	urlFormatNamed
+	urlFormatClean
+	urlFormatCount
+)
+
+// urlFormatNames are the names formats are stored under in the data and
+// config files.
+var urlFormatNames = [urlFormatCount]string{
+	urlFormatRaw:      "raw",
+	urlFormatMarkdown: "markdown",
+	urlFormatNamed:    "named",
+	urlFormatClean:    "clean",
+}
+
+func (f urlFormat) String() string {
+	return urlFormatNames[f]
+}
+
+// parseURLFormat looks up a format by its stored name.
+func parseURLFormat(name string) (urlFormat, bool) {
+	for f, n := range urlFormatNames {
+		if n == name {
+			return urlFormat(f), true
+		}
+	}
+	return urlFormatRaw, false
+}
+
+// nextProjectURLFormat cycles a project's URL format setting through every
+// format and then back to "" (use the global default).
+func nextProjectURLFormat(name string) string {
+	f, ok := parseURLFormat(name)
+	switch {
+	case !ok:
+		return urlFormatNames[0]
+	case f+1 == urlFormatCount:
+		return ""
+	default:
+		return urlFormatNames[f+1]
+	}
+}
+
+// formatURL renders u in the given clipboard format.
+func formatURL(u namedURL, f urlFormat, cfg Config) string {
+	switch f {
+	case urlFormatMarkdown:
+		return fmt.Sprintf("[%s](%s)", u.Name, u.URL)
+	case urlFormatNamed:
+		return fmt.Sprintf("%s — %s", u.Name, u.URL)
+	case urlFormatClean:
+		return stripTrackingParams(u.URL, cfg.StripClickIDs)
+	default:
+		return u.URL
+	}
+}
+
+// stripTrackingParams removes utm_* query parameters from raw, plus fbclid and
+// gclid when clickIDs is set. The remaining parameters keep their order and
+// unparseable URLs are returned unchanged.
+func stripTrackingParams(raw string, clickIDs bool) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.RawQuery == "" {
+		return raw
+	}
+
+	var kept []string
+	for _, param := range strings.Split(u.RawQuery, "&") {
+		key, _, _ := strings.Cut(param, "=")
+		if name, err := url.QueryUnescape(key); err == nil {
+			key = name
+		}
+		key = strings.ToLower(key)
+		if strings.HasPrefix(key, "utm_") || (clickIDs && (key == "fbclid" || key == "gclid")) {
+			continue
+		}
+		kept = append(kept, param)
+	}
+	u.RawQuery = strings.Join(kept, "&")
+	return u.String()
+}
+
+// parseURLLines reads a pasted block with one link per line, either a bare
+// URL or "Name<TAB>URL". Blank lines are ignored; lines without a valid
+// absolute URL are counted as skipped. Bare URLs are named after their domain.
+func parseURLLines(text string) (urls []namedURL, skipped int) {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		name, raw := "", line
+		if before, after, ok := strings.Cut(line, "\t"); ok {
+			name, raw = strings.TrimSpace(before), strings.TrimSpace(after)
+		}
+		u, err := url.Parse(raw)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			skipped++
+			continue
+		}
+		if name == "" {
+			name = strings.TrimPrefix(u.Hostname(), "www.")
+		}
+		urls = append(urls, namedURL{Name: name, URL: raw})
+	}
+	return urls, skipped
+}
+
+// normalizeURL checks a URL typed into the form. A missing scheme is taken
+// to mean https, so "example.com/docs" becomes "https://example.com/docs";
+// anything that still lacks a host is rejected.
+func normalizeURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.ParseRequestURI(raw)
+	if err != nil {
+		return "", fmt.Errorf("'%s' is not a valid URL", raw)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("'%s' has no host", raw)
+	}
+	return raw, nil
+}
+
+// urlSort selects the order URLs are listed in. Like colorSort it only affects
+// the display.
+type urlSort int
+
+const (
+	urlSortManual urlSort = iota
+	urlSortName
+	urlSortCount
+)
+
+// urlSortNames are the names sorts are stored under in the data file.
+var urlSortNames = [urlSortCount]string{
+	urlSortManual: "manual",
+	urlSortName:   "name",
+}
+
+func (s urlSort) String() string {
+	return urlSortNames[s]
+}
+
+func (s urlSort) next() urlSort {
+	return (s + 1) % urlSortCount
+}
+
+// parseURLSort looks up a stored sort name. Unknown and empty names mean
+// manual order.
+func parseURLSort(name string) urlSort {
+	for s, n := range urlSortNames {
+		if n == name {
+			return urlSort(s)
+		}
+	}
+	return urlSortManual
+}
+
+// urlOrder returns indexes into urls in the order s lists them.
+func urlOrder(urls []namedURL, s urlSort) []int {
+	order := make([]int, len(urls))
+	for i := range order {
+		order[i] = i
+	}
+	if s == urlSortName {
+		sort.SliceStable(order, func(a, b int) bool {
+			return strings.ToLower(urls[order[a]].Name) < strings.ToLower(urls[order[b]].Name)
+		})
+	}
+	return order
+}
+
+// URL name policies for the duplicateURLNames option.
+const (
+	urlNamesReject       = "reject"
+	urlNamesDisambiguate = "disambiguate"
+	urlNamesAllow        = "allow"
+)
+
+// resolveURLName applies the duplicate name policy to a URL being added or
+// renamed. skip is the index of the URL being edited, or -1 when adding. With
+// "disambiguate" a taken name gets the URL's domain appended, then a number if
+// that is taken too.
+func resolveURLName(urls []namedURL, skip int, name, rawURL, policy string) (string, error) {
+	taken := func(candidate string) bool {
+		for i, u := range urls {
+			if i != skip && u.Name == candidate {
+				return true
+			}
+		}
+		return false
+	}
+	if policy == urlNamesAllow || !taken(name) {
+		return name, nil
+	}
+	if policy != urlNamesDisambiguate {
+		return "", fmt.Errorf("a URL named '%s' already exists", name)
+	}
+
+	if u, err := url.Parse(rawURL); err == nil && u.Hostname() != "" {
+		name = fmt.Sprintf("%s (%s)", name, strings.TrimPrefix(u.Hostname(), "www."))
+		if !taken(name) {
+			return name, nil
+		}
+	}
+	for n := 2; ; n++ {
+		if candidate := fmt.Sprintf("%s (%d)", name, n); !taken(candidate) {
+			return candidate, nil
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+	"time"
+)
+
+const welcomeProjectName = "Welcome"
+
+// welcomeProject is the sample project seeded on a first run so new users have
+// something to explore.
+func welcomeProject() Project {
+	return Project{
+		Name: welcomeProjectName,
+		Colors: []Color{
+			{Hex: "#ff6b9d", Name: "pink"},
+			{Hex: "#7dd3fc", Name: "sky"},
+			{Hex: "#fde68a", Name: "butter"},
+		},
+		Urls: []namedURL{
+			{Name: "Diamonds docs", URL: "https://github.com/lynn-twinkl/diamonds#readme"},
+		},
+		Pinned:    "A sample project. Press d in the project list to delete it.",
+		CreatedAt: time.Now(),
+	}
+}
+
+// shouldSeedWelcome reports whether this is a first run that should get the
+// welcome project: seeding is on, hasn't happened before, and there is no data
+// file yet.
+func shouldSeedWelcome(cfg Config) bool {
+	if !cfg.SeedWelcome || cfg.Seeded {
+		return false
+	}
+	path, err := getDataFilePath()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return os.IsNotExist(err)
+}